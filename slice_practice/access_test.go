@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"slice_practice/sliceaccess"
+)
+
+// 方法1〜3は sliceaccess の関数の薄いラッパーなので、どの入力でも MiddleLookup と同じ値とエラーを返す
+func TestAccessMiddleMapStrategies(t *testing.T) {
+	strategies := []struct {
+		name   string
+		access func([]map[string]int, string) (int, error)
+	}{
+		{"safe", safeAccessMiddleMap},
+		{"efficient", efficientAccessMiddleMap},
+		{"pointer", pointerAccessMiddleMap},
+	}
+	tests := []struct {
+		name  string
+		slice []map[string]int
+		key   string
+	}{
+		{"found", createLargeSlice(5), "key2"},
+		{"even length", createLargeSlice(4), "key3"},
+		{"empty", nil, "key1"},
+		{"nil map", []map[string]int{{}, nil, {}}, "key1"},
+		{"missing key", createLargeSlice(5), "missing"},
+	}
+	for _, tt := range tests {
+		want, wantErr := sliceaccess.MiddleLookup(tt.slice, tt.key)
+		for _, s := range strategies {
+			got, err := s.access(tt.slice, tt.key)
+			if got != want || !sameError(err, wantErr) {
+				t.Errorf("%s/%s = %d, %v; want %d, %v", tt.name, s.name, got, err, want, wantErr)
+			}
+		}
+	}
+}
+
+// sameError は err と want が同じ原因のエラー（どちらも nil を含む）かどうかを返します
+func sameError(err, want error) bool {
+	var got, wantKey *sliceaccess.KeyNotFoundError
+	if errors.As(want, &wantKey) {
+		return errors.As(err, &got) && *got == *wantKey
+	}
+	return errors.Is(err, want)
+}
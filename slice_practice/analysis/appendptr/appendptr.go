@@ -0,0 +1,325 @@
+// Package appendptr は、sliceの要素へのポインタを保持したまま
+// そのsliceへappendし、古いポインタを使っている箇所を検出するアナライザーです。
+//
+//	p := &s[i]
+//	s = append(s, x)   // 内部配列が再割り当てされる可能性がある
+//	use(*p)            // p は古い内部配列を指しているかもしれない
+//
+// append([]T{x}, s...) のような先頭挿入も同様に検出します。
+// 判定は関数の制御フローグラフ（ctrlflow）に沿って行うので、appendした分岐がそのまま
+// return する場合は報告せず、ループの後半のappendは次の反復での参照として報告します。
+package appendptr
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/cfg"
+)
+
+const doc = `report element pointers that are used after the slice is grown by append
+
+A pointer taken with &s[i] keeps pointing into the old backing array after
+s = append(s, ...) or s = append([]T{x}, s...) reallocates it. Re-index the
+slice instead of holding the pointer across the append.`
+
+// sliceaccessPath は要素へのポインタを返す関数を持つパッケージです
+const sliceaccessPath = "slice_practice/sliceaccess"
+
+// Analyzer はappendをまたいで保持された要素ポインタを報告します
+var Analyzer = &analysis.Analyzer{
+	Name:     "appendptr",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer, ctrlflow.Analyzer},
+	Run:      run,
+}
+
+// fact は「ptr は slice の要素を指している」という事実です。
+// append が NoPos でなければ、その位置の append で slice を伸ばした後も、
+// ptr が伸ばす前の要素を指したままであることを表します。
+type fact struct {
+	ptr, slice types.Object
+	append     token.Pos
+}
+
+// facts はある地点に到達しうる事実の集合です
+type facts map[fact]bool
+
+// kill は ptr に関する事実をすべて取り除きます
+func (fs facts) kill(ptr types.Object) {
+	maps.DeleteFunc(fs, func(f fact, _ bool) bool { return f.ptr == ptr })
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	cfgs := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Body != nil {
+				checkFunc(pass, cfgs.FuncDecl(n))
+			}
+		case *ast.FuncLit:
+			checkFunc(pass, cfgs.FuncLit(n))
+		}
+	})
+	return nil, nil
+}
+
+// checkFunc は関数の制御フローグラフ上で、各地点に到達しうる事実を求めてから、
+// appendの後に古いポインタを参照している箇所を報告します。
+// appendしてから return する分岐は後続の参照に届かず、ループの後半のappendは
+// 戻りの辺を通って次の反復の参照に届きます。
+func checkFunc(pass *analysis.Pass, g *cfg.CFG) {
+	if len(g.Blocks) == 0 {
+		return
+	}
+
+	// in[i] はブロック i の入口に到達しうる事実（nil なら到達しない）
+	in := make([]facts, len(g.Blocks))
+	in[0] = facts{}
+	work := []*cfg.Block{g.Blocks[0]}
+	for len(work) > 0 {
+		b := work[len(work)-1]
+		work = work[:len(work)-1]
+
+		out := maps.Clone(in[b.Index])
+		for _, n := range b.Nodes {
+			transfer(pass, out, n)
+		}
+		for _, succ := range b.Succs {
+			changed := in[succ.Index] == nil
+			if changed {
+				in[succ.Index] = facts{}
+			}
+			for f := range out {
+				if !in[succ.Index][f] {
+					in[succ.Index][f] = true
+					changed = true
+				}
+			}
+			if changed {
+				work = append(work, succ)
+			}
+		}
+	}
+
+	// 同じappendについては、ポインタごとに一度だけ報告する
+	reported := make(map[fact]bool)
+	for _, b := range g.Blocks {
+		if in[b.Index] == nil {
+			continue
+		}
+		state := maps.Clone(in[b.Index])
+		for _, n := range b.Nodes {
+			checkUses(pass, state, reported, n)
+			transfer(pass, state, n)
+		}
+	}
+}
+
+// transfer はノード n の代入を state に反映します
+func transfer(pass *analysis.Pass, state facts, n ast.Node) {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		checkAssign(pass, state, n.Lhs, n.Rhs, n.Pos())
+	case *ast.ValueSpec:
+		lhs := make([]ast.Expr, len(n.Names))
+		for i, name := range n.Names {
+			lhs[i] = name
+		}
+		checkAssign(pass, state, lhs, n.Values, n.Pos())
+	}
+}
+
+// checkAssign はポインタの取得と、sliceを伸ばすappendを記録します
+func checkAssign(pass *analysis.Pass, state facts, lhs, rhs []ast.Expr, pos token.Pos) {
+	if len(lhs) != len(rhs) {
+		// v, err := f() のような多値代入では、左辺のポインタ追跡をやめる
+		for _, l := range lhs {
+			if obj := objectOf(pass, l); obj != nil {
+				state.kill(obj)
+			}
+		}
+		// p, err := sliceaccess.MiddleRef(s)
+		if len(rhs) == 1 {
+			if obj := objectOf(pass, lhs[0]); obj != nil {
+				if slice := elemAddrSlice(pass, rhs[0]); slice != nil {
+					state[fact{ptr: obj, slice: slice}] = true
+				}
+			}
+		}
+		return
+	}
+
+	for i, l := range lhs {
+		obj := objectOf(pass, l)
+		if obj == nil {
+			continue
+		}
+		state.kill(obj)
+
+		// p := &s[i]
+		if slice := elemAddrSlice(pass, rhs[i]); slice != nil {
+			state[fact{ptr: obj, slice: slice}] = true
+			continue
+		}
+
+		// s = append(s, ...) / s = append([]T{x}, s...)
+		if isAppendOf(pass, rhs[i], obj) {
+			var grown []fact
+			for f := range state {
+				if f.slice == obj && f.append == token.NoPos {
+					grown = append(grown, fact{ptr: f.ptr, slice: f.slice, append: pos})
+				}
+			}
+			for _, f := range grown {
+				state[f] = true
+			}
+		}
+	}
+}
+
+// checkUses はノード n の中で、append後に古いポインタを参照している箇所を報告します。
+// 関数リテラルの中は、その関数の制御フローグラフで調べます。
+func checkUses(pass *analysis.Pass, state facts, reported map[fact]bool, n ast.Node) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.StarExpr:
+			checkUse(pass, state, reported, n.X, n.Pos())
+		case *ast.SelectorExpr:
+			// p.Field は暗黙的に *p を参照する
+			if obj := objectOf(pass, n.X); obj != nil {
+				if _, ok := obj.Type().Underlying().(*types.Pointer); ok {
+					checkUse(pass, state, reported, n.X, n.Pos())
+				}
+			}
+		}
+		return true
+	})
+}
+
+// checkUse は x が古いポインタであれば、最後にsliceを伸ばしたappendの行とともに報告します
+func checkUse(pass *analysis.Pass, state facts, reported map[fact]bool, x ast.Expr, pos token.Pos) {
+	obj := objectOf(pass, x)
+	if obj == nil {
+		return
+	}
+	var stale []fact
+	var latest fact
+	for f := range state {
+		if f.ptr != obj || f.append == token.NoPos {
+			continue
+		}
+		stale = append(stale, f)
+		if !reported[f] && f.append > latest.append {
+			latest = f
+		}
+	}
+	if latest.append == token.NoPos {
+		return
+	}
+	for _, f := range stale {
+		reported[f] = true
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos: pos,
+		End: x.End(),
+		Message: fmt.Sprintf("%s points into %s, which may have been reallocated by append on line %d; re-index %s instead of holding the pointer",
+			obj.Name(), latest.slice.Name(), pass.Fset.Position(latest.append).Line, latest.slice.Name()),
+	})
+}
+
+// elemAddrSlice は e が &s[i] の形であれば s のオブジェクトを返します。
+// sliceaccess.TrackRef(s, i) と sliceaccess.MiddleRef(s) も同じように扱います。
+func elemAddrSlice(pass *analysis.Pass, e ast.Expr) types.Object {
+	if call, ok := ast.Unparen(e).(*ast.CallExpr); ok {
+		if isRefFunc(pass, call.Fun) && len(call.Args) > 0 {
+			return objectOf(pass, call.Args[0])
+		}
+		return nil
+	}
+
+	unary, ok := ast.Unparen(e).(*ast.UnaryExpr)
+	if !ok || unary.Op != token.AND {
+		return nil
+	}
+	index, ok := ast.Unparen(unary.X).(*ast.IndexExpr)
+	if !ok {
+		return nil
+	}
+	if _, ok := pass.TypesInfo.TypeOf(index.X).Underlying().(*types.Slice); !ok {
+		return nil
+	}
+	return objectOf(pass, index.X)
+}
+
+// isRefFunc は fun が要素へのポインタを返す sliceaccess の関数かどうかを返します
+func isRefFunc(pass *analysis.Pass, fun ast.Expr) bool {
+	fun = ast.Unparen(fun)
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		// 明示的な型引数 TrackRef[T](s, i)
+		fun = index.X
+	}
+	var id *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		id = f
+	case *ast.SelectorExpr:
+		id = f.Sel
+	default:
+		return false
+	}
+	fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != sliceaccessPath {
+		return false
+	}
+	return fn.Name() == "TrackRef" || fn.Name() == "MiddleRef"
+}
+
+// isAppendOf は e が slice を引数に含む append の呼び出しかどうかを返します
+func isAppendOf(pass *analysis.Pass, e ast.Expr, slice types.Object) bool {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	if _, ok := pass.TypesInfo.Uses[fn].(*types.Builtin); !ok || fn.Name != "append" {
+		return false
+	}
+	for _, arg := range call.Args {
+		if objectOf(pass, arg) == slice {
+			return true
+		}
+	}
+	return false
+}
+
+// objectOf は識別子が参照する変数を返します
+func objectOf(pass *analysis.Pass, e ast.Expr) types.Object {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	if v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var); ok {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package appendptr_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"slice_practice/analysis/appendptr"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), appendptr.Analyzer, "appendptr")
+}
@@ -0,0 +1,140 @@
+package appendptr
+
+import (
+	"fmt"
+
+	"slice_practice/sliceaccess"
+)
+
+// demonstratePointerDanger と同じ手順
+func demonstratePointerDanger(slice []map[string]int) {
+	middleIndex := len(slice) / 2
+	middleMapPtr := &slice[middleIndex]
+	fmt.Printf("ポインタで取得したmap: %v\n", *middleMapPtr)
+
+	slice = append(slice, map[string]int{"new": 999})
+	fmt.Printf("ポインタが指している先: %v\n", *middleMapPtr) // want `middleMapPtr points into slice, which may have been reallocated by append on line 15`
+
+	// 先頭挿入
+	slice = append([]map[string]int{{"inserted": 888}}, slice...)
+	fmt.Printf("ポインタが指している先: %v\n", *middleMapPtr) // want `middleMapPtr points into slice, which may have been reallocated by append on line 19`
+}
+
+// demonstrateDetailedDanger と同じ手順
+func demonstrateDetailedDanger() {
+	slice := make([]map[string]int, 3)
+	for i := 0; i < 3; i++ {
+		slice[i] = map[string]int{"value": i * 10}
+	}
+
+	middlePtr := &slice[1]
+	fmt.Printf("真ん中の要素のポインタ: %p, 値: %v\n", middlePtr, *middlePtr)
+
+	slice = append(slice, make([]map[string]int, 1000)...)
+	fmt.Printf("元のポインタが指している先: %p, 値: %v\n", middlePtr, *middlePtr) // want `middlePtr points into slice`
+	fmt.Printf("もう一度: %v\n", *middlePtr)                            // 同じappendについては一度だけ報告
+}
+
+type item struct {
+	ID int
+}
+
+// フィールドアクセスは暗黙的な参照として扱う
+func fieldAccess(items []item) int {
+	var p = &items[0]
+	items = append(items, item{ID: 1})
+	return p.ID // want `p points into items`
+}
+
+// appendの引数として使うのは、append前の参照なので問題ない
+func useInsideAppend(slice []int) []int {
+	p := &slice[0]
+	slice = append(slice, *p)
+	return slice
+}
+
+// append後にポインタを取り直せば問題ない
+func reindex(slice []int) int {
+	p := &slice[0]
+	slice = append(slice, 1)
+	p = &slice[0]
+	return *p
+}
+
+// 別のsliceへのappendは関係ない
+func otherSlice(slice, other []int) int {
+	p := &slice[0]
+	other = append(other, 1)
+	_ = other
+	return *p
+}
+
+// getValueWithPointer のように一度だけ使うのは安全
+func getValueWithPointer(slice []map[string]int, key string) int {
+	if len(slice) == 0 {
+		return 0
+	}
+	middlePtr := &slice[len(slice)/2]
+	if *middlePtr == nil {
+		return 0
+	}
+	return (*middlePtr)[key]
+}
+
+// sliceaccess.TrackRef で取得した参照も &s[i] と同じ
+func trackRef(slice []map[string]int) {
+	middlePtr := sliceaccess.TrackRef(slice, 1)
+	slice = append(slice, make([]map[string]int, 1000)...)
+	fmt.Println(*middlePtr) // want `middlePtr points into slice`
+}
+
+// sliceaccess.MiddleRef も同じ
+func middleRef(slice []int) int {
+	p, err := sliceaccess.MiddleRef(slice)
+	if err != nil {
+		return 0
+	}
+	slice = append([]int{0}, slice...)
+	return *p // want `p points into slice`
+}
+
+// appendした分岐がそのまま return するなら、その後の参照には古いポインタが届かない
+func appendAndReturn(slice []int, c bool) int {
+	p := &slice[0]
+	if c {
+		slice = append(slice, 1)
+		return len(slice)
+	}
+	return *p
+}
+
+// 片方の分岐でappendすると、合流した後の参照は古いポインタかもしれない
+func appendInBranch(slice []int, c bool) int {
+	p := &slice[0]
+	if c {
+		slice = append(slice, 1)
+	}
+	return *p // want `p points into slice, which may have been reallocated by append on line 115`
+}
+
+// ループの後半でappendすると、次の反復の参照は古いポインタを使う
+func appendInLoop(slice []int) int {
+	p := &slice[0]
+	sum := 0
+	for i := range 10 {
+		sum += *p // want `p points into slice, which may have been reallocated by append on line 126`
+		slice = append(slice, i)
+	}
+	return sum
+}
+
+// ループの中でポインタを取り直せば問題ない
+func reindexInLoop(slice []int) int {
+	sum := 0
+	for i := range 10 {
+		p := &slice[0]
+		sum += *p
+		slice = append(slice, i)
+	}
+	return sum
+}
@@ -0,0 +1,10 @@
+// Package sliceaccess はテスト用に slice_practice/sliceaccess の関数を模したものです
+package sliceaccess
+
+func TrackRef[T any](slice []T, i int) *T {
+	return &slice[i]
+}
+
+func MiddleRef[T any](slice []T) (*T, error) {
+	return &slice[len(slice)/2], nil
+}
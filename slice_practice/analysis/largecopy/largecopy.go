@@ -0,0 +1,142 @@
+// Package largecopy は、大きな構造体を値としてコピーしている箇所を検出するアナライザーです。
+//
+// getLargeStructWithIndex のように [1000]int を持つ構造体を値で返すと、
+// 呼び出しのたびに約8KBがコピーされます。return、rangeの変数、代入のうち、
+// しきい値（-threshold）を超える構造体をコピーしているものを報告します。
+// サイズは対象アーキテクチャの types.Sizes で計算します。
+package largecopy
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `report copies of large structs by value
+
+Returning, ranging over or assigning a struct value copies the whole struct.
+Structs larger than -threshold bytes (computed with the target GOARCH sizes)
+are reported with a suggestion to use a pointer or index access instead.`
+
+// DefaultThreshold は -threshold を指定しないときのしきい値（バイト）です
+const DefaultThreshold = 1024
+
+// Analyzer は大きな構造体の値コピーを報告します
+var Analyzer = &analysis.Analyzer{
+	Name:     "largecopy",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// threshold はこのバイト数を超える構造体のコピーを報告します
+var threshold int64
+
+func init() {
+	Analyzer.Flags.Int64Var(&threshold, "threshold", DefaultThreshold, "report struct copies larger than this many bytes")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.ReturnStmt)(nil),
+		(*ast.RangeStmt)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				if size, ok := copiedSize(pass, result); ok {
+					pass.Reportf(result.Pos(), "return copies %d-byte %s; return a pointer or let the caller access the element by index",
+						size, typeString(pass, result))
+				}
+			}
+		case *ast.RangeStmt:
+			checkRange(pass, n)
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i, rhs := range n.Rhs {
+					checkAssign(pass, n.Lhs[i], rhs)
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i, value := range n.Values {
+					checkAssign(pass, n.Names[i], value)
+				}
+			}
+		}
+	})
+	return nil, nil
+}
+
+// checkRange は for _, v := range s で要素がコピーされる箇所を報告します
+func checkRange(pass *analysis.Pass, n *ast.RangeStmt) {
+	if n.Value == nil || isBlank(n.Value) {
+		return
+	}
+	elem := pass.TypesInfo.TypeOf(n.Value)
+	if elem == nil {
+		return
+	}
+	size, ok := largeStructSize(pass, elem)
+	if !ok {
+		return
+	}
+	pass.Reportf(n.Value.Pos(), "range variable %s copies %d-byte %s on every iteration; range over the index and use a pointer to the element instead",
+		types.ExprString(n.Value), size, types.TypeString(elem, types.RelativeTo(pass.Pkg)))
+}
+
+// checkAssign は x := s[i] のような代入でのコピーを報告します
+func checkAssign(pass *analysis.Pass, lhs, rhs ast.Expr) {
+	if isBlank(lhs) {
+		return
+	}
+	if size, ok := copiedSize(pass, rhs); ok {
+		pass.Reportf(rhs.Pos(), "assignment copies %d-byte %s; use a pointer or index access instead",
+			size, typeString(pass, rhs))
+	}
+}
+
+// copiedSize は e を値として使うと既存の構造体がコピーされる場合にそのサイズを返します。
+// 複合リテラルや関数呼び出しの結果は新しい値なので対象外です。
+func copiedSize(pass *analysis.Pass, e ast.Expr) (int64, bool) {
+	switch ast.Unparen(e).(type) {
+	case *ast.Ident, *ast.IndexExpr, *ast.SelectorExpr, *ast.StarExpr:
+	default:
+		return 0, false
+	}
+	tv, ok := pass.TypesInfo.Types[e]
+	if !ok || !tv.IsValue() {
+		return 0, false
+	}
+	// nil や定数はコピーではない
+	if tv.IsNil() || tv.Value != nil {
+		return 0, false
+	}
+	return largeStructSize(pass, tv.Type)
+}
+
+// largeStructSize は t がしきい値を超える構造体であればそのサイズを返します
+func largeStructSize(pass *analysis.Pass, t types.Type) (int64, bool) {
+	if _, ok := t.Underlying().(*types.Struct); !ok {
+		return 0, false
+	}
+	size := pass.TypesSizes.Sizeof(t)
+	return size, size > threshold
+}
+
+func typeString(pass *analysis.Pass, e ast.Expr) string {
+	return types.TypeString(pass.TypesInfo.TypeOf(e), types.RelativeTo(pass.Pkg))
+}
+
+func isBlank(e ast.Expr) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == "_"
+}
@@ -0,0 +1,25 @@
+package largecopy_test
+
+import (
+	"strconv"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"slice_practice/analysis/largecopy"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), largecopy.Analyzer, "largecopy")
+}
+
+// -threshold を変えると、しきい値を超えた構造体のコピーだけが報告される
+func TestAnalyzerThreshold(t *testing.T) {
+	if err := largecopy.Analyzer.Flags.Set("threshold", "64"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		largecopy.Analyzer.Flags.Set("threshold", strconv.Itoa(largecopy.DefaultThreshold))
+	})
+	analysistest.Run(t, analysistest.TestData(), largecopy.Analyzer, "threshold")
+}
@@ -0,0 +1,68 @@
+package largecopy
+
+// slice_practice の LargeStruct と同じ定義
+type LargeStruct struct {
+	ID       int
+	Name     string
+	Data     [1000]int
+	Metadata map[string]interface{}
+	Values   []float64
+}
+
+type smallStruct struct {
+	ID   int
+	Name string
+}
+
+// getLargeStructWithIndex と同じく値で返すと構造体全体がコピーされる
+func getLargeStructWithIndex(slice []LargeStruct) LargeStruct {
+	if len(slice) == 0 {
+		return LargeStruct{}
+	}
+	middleIndex := len(slice) / 2
+	return slice[middleIndex] // want `return copies 8\d{3}-byte LargeStruct`
+}
+
+// getLargeStructWithPointer はポインタだけを返すので問題ない
+func getLargeStructWithPointer(slice []LargeStruct) *LargeStruct {
+	if len(slice) == 0 {
+		return nil
+	}
+	return &slice[len(slice)/2]
+}
+
+// analyzeAccessPatterns と同じアクセス
+func analyzeAccessPatterns(slice []LargeStruct) int {
+	total := 0
+	for i := 0; i < 10; i++ {
+		ptr := getLargeStructWithPointer(slice)
+		total += ptr.ID
+	}
+
+	// 関数呼び出しの結果は return 側で報告済み
+	val := getLargeStructWithIndex(slice)
+	total += val.ID
+
+	middle := slice[len(slice)/2] // want `assignment copies 8\d{3}-byte LargeStruct`
+	total += middle.ID
+
+	var deref = *getLargeStructWithPointer(slice) // want `assignment copies 8\d{3}-byte LargeStruct`
+	total += deref.ID
+
+	for _, s := range slice { // want `range variable s copies 8\d{3}-byte LargeStruct on every iteration`
+		total += s.ID
+	}
+	for i := range slice {
+		total += slice[i].ID
+	}
+	return total
+}
+
+// 小さな構造体のコピーは報告しない
+func small(items []smallStruct) smallStruct {
+	for _, item := range items {
+		_ = item
+	}
+	first := items[0]
+	return first
+}
@@ -0,0 +1,16 @@
+package threshold
+
+// -threshold=64 で解析する（int64 を使い、どのアーキテクチャでも同じサイズにしている）
+
+type under struct{ a [7]int64 } // 56 bytes
+
+type exact struct{ a [8]int64 } // 64 bytes（しきい値ちょうどは報告しない）
+
+type over struct{ a [9]int64 } // 72 bytes
+
+func copies(u []under, e []exact, o []over) int {
+	x := u[0]
+	y := e[0]
+	z := o[0] // want `assignment copies 72-byte over`
+	return int(x.a[0] + y.a[0] + z.a[0])
+}
@@ -0,0 +1,226 @@
+// Package baseline は、計測結果をGoのバージョンとGOARCHごとにローカルのファイルへ保存し、
+// 後の計測結果と比較して性能の劣化（リグレッション）を検出します。
+package baseline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+	"time"
+
+	"slice_practice/report"
+)
+
+// FormatVersion はベースラインファイルの形式のバージョンです。
+// 形式を互換性のない形で変えたときに上げます。
+const FormatVersion = 1
+
+// Env は計測した環境です
+type Env struct {
+	GoVersion string `json:"go_version"` // runtime.Version() の値（go1.24.5 など）
+	GOARCH    string `json:"goarch"`
+}
+
+// Current は実行中の環境を返します
+func Current() Env {
+	return Env{GoVersion: runtime.Version(), GOARCH: runtime.GOARCH}
+}
+
+// String はファイル内でのキー（go1.24.5/amd64 など）を返します
+func (e Env) String() string {
+	return e.GoVersion + "/" + e.GOARCH
+}
+
+// Entry は1つの環境で記録した計測結果です
+type Entry struct {
+	Env
+	Recorded time.Time       `json:"recorded"`
+	Records  []report.Record `json:"records"`
+}
+
+// File はベースラインファイルの内容です
+type File struct {
+	Version int               `json:"version"`
+	Entries map[string]*Entry `json:"entries"` // キーは Env.String()
+}
+
+// Load は path のベースラインファイルを読み込みます。
+// ファイルが存在しない場合は空の File を返します。
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &File{Version: FormatVersion, Entries: map[string]*Entry{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("baseline %s: %w", path, err)
+	}
+	if f.Version != FormatVersion {
+		return nil, fmt.Errorf("baseline %s: unsupported format version %d (want %d)", path, f.Version, FormatVersion)
+	}
+	if f.Entries == nil {
+		f.Entries = map[string]*Entry{}
+	}
+	return &f, nil
+}
+
+// Save は f を path に書き出します
+func (f *File) Save(path string) error {
+	f.Version = FormatVersion
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Merge は env の計測結果に records を Key ごとに反映します。
+// 同じキーのレコードは置き換え、新しいキーは追加し、records にないキーはそのまま残します。
+// サブコマンドごとに -save-baseline しても、他のサブコマンドの結果は消えません。
+func (f *File) Merge(env Env, records []report.Record, now time.Time) {
+	e, ok := f.Entries[env.String()]
+	if !ok {
+		e = &Entry{Env: env}
+		f.Entries[env.String()] = e
+	}
+	e.Recorded = now
+	index := make(map[Key]int, len(e.Records))
+	for i, r := range e.Records {
+		index[KeyOf(r)] = i
+	}
+	for _, r := range records {
+		if i, ok := index[KeyOf(r)]; ok {
+			e.Records[i] = r
+			continue
+		}
+		index[KeyOf(r)] = len(e.Records)
+		e.Records = append(e.Records, r)
+	}
+}
+
+// Find は env と比較するエントリを返します。
+// 同じ環境のエントリがなければ、同じGOARCHで最も新しく記録されたエントリを返します
+// （Goのバージョンを上げたときの差分として比較するため）。
+func (f *File) Find(env Env) (*Entry, bool) {
+	if e, ok := f.Entries[env.String()]; ok {
+		return e, true
+	}
+	var found *Entry
+	for _, e := range f.Entries {
+		if e.GOARCH != env.GOARCH {
+			continue
+		}
+		if found == nil || e.Recorded.After(found.Recorded) {
+			found = e
+		}
+	}
+	return found, found != nil
+}
+
+// Key は比較のときにレコードを対応付けるキーです
+type Key struct {
+	Command     string
+	Scenario    string
+	Strategy    string
+	ElementType string
+	SliceLen    int
+}
+
+// KeyOf は r のキーを返します
+func KeyOf(r report.Record) Key {
+	return Key{r.Command, r.Scenario, r.Strategy, r.ElementType, r.SliceLen}
+}
+
+// String は表示用の文字列（bench/middle/safe [map[string]int, len=1000000] など）を返します
+func (k Key) String() string {
+	return fmt.Sprintf("%s/%s/%s [%s, len=%d]", k.Command, k.Scenario, k.Strategy, k.ElementType, k.SliceLen)
+}
+
+// Diff は1つのアクセス方法のベースラインとの差分です
+type Diff struct {
+	Key       Key
+	Base      float64 // ベースラインの ns/op（中央値）
+	Current   float64 // 今回の ns/op（中央値）
+	Change    float64 // 変化率（%）。正の値は遅くなったことを表す
+	Regressed bool    // Change がしきい値を超えた
+}
+
+// Comparison はベースラインと今回の計測結果の比較です
+type Comparison struct {
+	Base      Env
+	Current   Env
+	Threshold float64 // リグレッションとみなす変化率（%）
+	Diffs     []Diff
+	Added     []Key // ベースラインにないレコード
+	Removed   []Key // 今回実行したサブコマンドのレコードのうち、今回の計測にないもの
+}
+
+// CrossVersion はGoのバージョンが異なるベースラインと比較したかどうかを返します。
+// この場合の差分はGoのバージョンアップによるものとして扱い、失敗にはしません。
+func (c *Comparison) CrossVersion() bool {
+	return c.Base.GoVersion != c.Current.GoVersion
+}
+
+// Regressions はしきい値を超えて遅くなった差分を返します
+func (c *Comparison) Regressions() []Diff {
+	var regs []Diff
+	for _, d := range c.Diffs {
+		if d.Regressed {
+			regs = append(regs, d)
+		}
+	}
+	return regs
+}
+
+// Disjoint は今回の計測結果とベースラインに共通のキーが1つもないかどうかを返します。
+// 別のサブコマンドのベースラインと比較した場合などで、この比較ではリグレッションを検出できません。
+func (c *Comparison) Disjoint() bool {
+	return len(c.Diffs) == 0 && len(c.Added) > 0
+}
+
+// Failed は終了コードを非0にすべきかどうかを返します。
+// 同じ環境でリグレッションがあった場合と、共通のキーがなく比較できなかった場合です。
+func (c *Comparison) Failed() bool {
+	return c.Disjoint() || !c.CrossVersion() && len(c.Regressions()) > 0
+}
+
+// Compare はベースラインのエントリと今回の計測結果を比較します。
+// threshold は変化率（%）で、これを超えて遅くなったものをリグレッションとします。
+func Compare(base *Entry, current Env, records []report.Record, threshold float64) *Comparison {
+	c := &Comparison{Base: base.Env, Current: current, Threshold: threshold}
+	baseByKey := make(map[Key]report.Record, len(base.Records))
+	for _, r := range base.Records {
+		baseByKey[KeyOf(r)] = r
+	}
+	seen := make(map[Key]bool, len(records))
+	commands := make(map[string]bool)
+	for _, r := range records {
+		k := KeyOf(r)
+		seen[k] = true
+		commands[r.Command] = true
+		b, ok := baseByKey[k]
+		if !ok {
+			c.Added = append(c.Added, k)
+			continue
+		}
+		d := Diff{Key: k, Base: b.NsPerOp, Current: r.NsPerOp}
+		if b.NsPerOp > 0 {
+			d.Change = (r.NsPerOp - b.NsPerOp) / b.NsPerOp * 100
+		}
+		d.Regressed = d.Change > threshold
+		c.Diffs = append(c.Diffs, d)
+	}
+	// ベースラインには他のサブコマンドのレコードも入っているので、今回実行したものだけを見る
+	for _, r := range base.Records {
+		if k := KeyOf(r); !seen[k] && commands[r.Command] {
+			c.Removed = append(c.Removed, k)
+		}
+	}
+	return c
+}
@@ -0,0 +1,139 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"slice_practice/report"
+)
+
+func rec(strategy string, ns float64) report.Record {
+	return report.Record{Command: "bench", Scenario: "middle", Strategy: strategy, ElementType: "map[string]int", SliceLen: 1000, NsPerOp: ns}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load of missing file: %v", err)
+	}
+	if len(f.Entries) != 0 {
+		t.Fatalf("missing file has %d entries", len(f.Entries))
+	}
+
+	env := Env{GoVersion: "go1.24.5", GOARCH: "amd64"}
+	f.Merge(env, []report.Record{rec("safe", 10)}, time.Unix(0, 0))
+	if err := f.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	g, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := g.Entries["go1.24.5/amd64"]
+	if !ok || e.Env != env || len(e.Records) != 1 || e.Records[0].NsPerOp != 10 {
+		t.Errorf("round trip = %+v", g.Entries)
+	}
+}
+
+func TestLoadVersionMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load accepted an unknown format version")
+	}
+}
+
+func TestFind(t *testing.T) {
+	f := &File{Entries: map[string]*Entry{}}
+	f.Merge(Env{"go1.24.5", "amd64"}, nil, time.Unix(100, 0))
+	f.Merge(Env{"go1.24.6", "amd64"}, nil, time.Unix(200, 0))
+	f.Merge(Env{"go1.25.0", "arm64"}, nil, time.Unix(300, 0))
+
+	if e, ok := f.Find(Env{"go1.24.5", "amd64"}); !ok || e.GoVersion != "go1.24.5" {
+		t.Errorf("exact match = %v, %v", e, ok)
+	}
+	// 同じGOARCHで最も新しいエントリにフォールバックする
+	if e, ok := f.Find(Env{"go1.25.0", "amd64"}); !ok || e.GoVersion != "go1.24.6" {
+		t.Errorf("fallback = %v, %v", e, ok)
+	}
+	if _, ok := f.Find(Env{"go1.25.0", "386"}); ok {
+		t.Error("found an entry for another GOARCH")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	base := &Entry{
+		Env:     Env{"go1.24.5", "amd64"},
+		Records: []report.Record{rec("safe", 10), rec("efficient", 10), rec("removed", 10)},
+	}
+	current := []report.Record{rec("safe", 10.5), rec("efficient", 12), rec("added", 1)}
+
+	c := Compare(base, base.Env, current, 10)
+	if len(c.Diffs) != 2 {
+		t.Fatalf("Diffs = %+v", c.Diffs)
+	}
+	if c.Diffs[0].Regressed || c.Diffs[0].Change != 5 {
+		t.Errorf("safe diff = %+v; want +5%% without regression", c.Diffs[0])
+	}
+	if !c.Diffs[1].Regressed {
+		t.Errorf("efficient diff = %+v; want regression", c.Diffs[1])
+	}
+	if len(c.Added) != 1 || c.Added[0].Strategy != "added" {
+		t.Errorf("Added = %v", c.Added)
+	}
+	if len(c.Removed) != 1 || c.Removed[0].Strategy != "removed" {
+		t.Errorf("Removed = %v", c.Removed)
+	}
+	if !c.Failed() {
+		t.Error("regression in the same environment did not fail")
+	}
+
+	// Goのバージョンが異なる場合は差分として報告するだけで失敗にはしない
+	c = Compare(base, Env{"go1.25.0", "amd64"}, current, 10)
+	if !c.CrossVersion() || len(c.Regressions()) != 1 || c.Failed() {
+		t.Errorf("cross-version comparison = %+v", c)
+	}
+}
+
+// 別のサブコマンドで保存しても、先に保存したレコードは残る
+func TestMergeKeepsOtherKeys(t *testing.T) {
+	env := Env{"go1.24.5", "amd64"}
+	f := &File{Entries: map[string]*Entry{}}
+	f.Merge(env, []report.Record{rec("safe", 10), rec("efficient", 20)}, time.Unix(100, 0))
+
+	large := rec("getLargeStructWithPointer", 5)
+	large.Command = "large"
+	f.Merge(env, []report.Record{rec("safe", 11), large}, time.Unix(200, 0))
+
+	e := f.Entries[env.String()]
+	if len(e.Records) != 3 || !e.Recorded.Equal(time.Unix(200, 0)) {
+		t.Fatalf("merged entry = %+v", e)
+	}
+	want := map[string]float64{"safe": 11, "efficient": 20, "getLargeStructWithPointer": 5}
+	for _, r := range e.Records {
+		if want[r.Strategy] != r.NsPerOp {
+			t.Errorf("%s = %v ns/op; want %v", r.Strategy, r.NsPerOp, want[r.Strategy])
+		}
+	}
+}
+
+func TestCompareDisjoint(t *testing.T) {
+	base := &Entry{Env: Env{"go1.24.5", "amd64"}, Records: []report.Record{rec("safe", 10)}}
+	large := rec("getLargeStructWithPointer", 5)
+	large.Command = "large"
+
+	c := Compare(base, base.Env, []report.Record{large}, 10)
+	if !c.Disjoint() || !c.Failed() {
+		t.Errorf("comparison without common keys = %+v; want Disjoint and Failed", c)
+	}
+	// 今回実行していないサブコマンドのレコードは Removed にしない
+	base.Records = append(base.Records, large)
+	if c = Compare(base, base.Env, []report.Record{rec("safe", 10)}, 10); c.Disjoint() || c.Failed() || len(c.Removed) != 0 {
+		t.Errorf("matching comparison = %+v", c)
+	}
+}
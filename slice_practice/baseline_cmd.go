@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"slice_practice/baseline"
+	"slice_practice/report"
+)
+
+// baselineOptions はベースラインに関するフラグです
+type baselineOptions struct {
+	path      string  // ベースラインファイル（空なら比較しない）
+	save      bool    // 今回の計測結果をベースラインとして保存する
+	threshold float64 // リグレッションとみなす変化率（%）
+}
+
+// benchmarkRecords は records からベースラインの対象（計測ループの結果）だけを取り出します。
+// sliceの作成などの区間は1回しか計測しないため、比較の対象にしません。
+func benchmarkRecords(records []report.Record) []report.Record {
+	var recs []report.Record
+	for _, r := range records {
+		if r.Scenario != "setup" {
+			recs = append(recs, r)
+		}
+	}
+	return recs
+}
+
+// checkBaseline は今回の計測結果をベースラインファイルと比較するか、ベースラインとして保存し、
+// 終了コードを返します。同じGoのバージョンとGOARCHでリグレッションがあるか、
+// ベースラインと共通の計測が1つもなければ1を返します。
+func checkBaseline(w io.Writer, bo baselineOptions, records []report.Record) int {
+	f, err := baseline.Load(bo.path)
+	if err != nil {
+		msg.Fprintf(w, "エラー: %v\n", err)
+		return 1
+	}
+	env := baseline.Current()
+	recs := benchmarkRecords(records)
+
+	if bo.save {
+		f.Merge(env, recs, time.Now())
+		if err := f.Save(bo.path); err != nil {
+			msg.Fprintf(w, "エラー: %v\n", err)
+			return 1
+		}
+		msg.Fprintf(w, "\nベースラインを保存しました: %s (%s, %d件)\n", bo.path, env, len(recs))
+		return 0
+	}
+
+	entry, ok := f.Find(env)
+	if !ok {
+		msg.Fprintf(w, "\n%s のベースラインがありません（-save-baseline で保存できます）\n", env)
+		return 0
+	}
+	c := baseline.Compare(entry, env, recs, bo.threshold)
+	printBaselineComparison(w, c)
+	if c.Failed() {
+		return 1
+	}
+	return 0
+}
+
+// printBaselineComparison はベースラインとの比較結果を書き出します
+func printBaselineComparison(w io.Writer, c *baseline.Comparison) {
+	msg.Fprintf(w, "\n=== ベースラインとの比較（%s → %s, しきい値 +%.1f%%）===\n", c.Base, c.Current, c.Threshold)
+	if c.CrossVersion() {
+		msg.Fprintf(w, "ℹ️  Goのバージョンが異なるベースラインと比較しています（%s → %s）。\n", c.Base.GoVersion, c.Current.GoVersion)
+		msg.Fprintln(w, "   以下の差分はGoのバージョンアップによるものとして扱い、失敗にはしません。")
+	}
+	for _, d := range c.Diffs {
+		mark := "  "
+		if d.Regressed {
+			mark = "❌"
+		}
+		fmt.Fprintf(w, "%s %s: %.2f → %.2f ns/op (%+.1f%%)\n", mark, keyLabel(d.Key), d.Base, d.Current, d.Change)
+	}
+	for _, k := range c.Added {
+		msg.Fprintf(w, "   %s: ベースラインにありません\n", keyLabel(k))
+	}
+	for _, k := range c.Removed {
+		msg.Fprintf(w, "   %s: 今回は計測していません\n", keyLabel(k))
+	}
+
+	regs := c.Regressions()
+	switch {
+	case c.Disjoint():
+		msg.Fprintln(w, "❌ ベースラインと共通の計測がありません（同じサブコマンドで -save-baseline したか確認してください）")
+	case len(regs) == 0:
+		msg.Fprintln(w, "✅ リグレッションはありません")
+	case c.CrossVersion():
+		msg.Fprintf(w, "⚠️  %d件がしきい値を超えて遅くなりました（Goのバージョンの違いによる差分）\n", len(regs))
+	default:
+		msg.Fprintf(w, "❌ %d件がしきい値を超えて遅くなりました\n", len(regs))
+	}
+}
+
+// keyLabel はアクセス方法の名前を表示言語に翻訳したキーの文字列を返します
+func keyLabel(k baseline.Key) string {
+	k.Strategy = msg.T(k.Strategy)
+	return k.String()
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"slice_practice/sliceaccess"
+)
+
+// ベンチマークで使うsliceの長さ
+var benchLengths = []int{1000, 100000, 1000000}
+
+// 結果をパッケージ変数に書き込み、コンパイラにループを消されないようにする
+var (
+	intSink         int
+	largeStructSink LargeStruct
+	largePtrSink    *LargeStruct
+	elementSink     any
+)
+
+// 各アクセス方法（方法1〜3、getValueWith*、sliceaccess）の比較
+func BenchmarkAccessMiddleMap(b *testing.B) {
+	strategies := []struct {
+		name   string
+		access func([]map[string]int, string) int
+	}{
+		{"safe", benchmarkSafeAccess},
+		{"efficient", benchmarkEfficientAccess},
+		{"pointer", benchmarkPointerAccess},
+		{"getValueWithPointer", getValueWithPointer},
+		{"getValueWithIndex", getValueWithIndex},
+		{"sliceaccess.MiddleLookup", func(slice []map[string]int, key string) int {
+			value, _ := sliceaccess.MiddleLookup(slice, key)
+			return value
+		}},
+	}
+
+	for _, length := range benchLengths {
+		slice := createLargeSlice(length)
+		for _, s := range strategies {
+			b.Run(fmt.Sprintf("%s/len=%d", s.name, length), func(b *testing.B) {
+				b.ReportAllocs()
+				sum := 0
+				for i := 0; i < b.N; i++ {
+					sum += s.access(slice, "key1")
+				}
+				intSink = sum
+			})
+		}
+	}
+}
+
+// LargeStruct をポインタで取得する場合と値で取得する場合の比較
+func BenchmarkLargeStructAccess(b *testing.B) {
+	// createLargeStructSlice は1要素あたり約8KBなので長さを抑える
+	for _, length := range []int{1000, 100000} {
+		slice := createLargeStructSlice(length)
+
+		b.Run(fmt.Sprintf("pointer/len=%d", length), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				largePtrSink = getLargeStructWithPointer(slice)
+			}
+		})
+
+		b.Run(fmt.Sprintf("index/len=%d", length), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				largeStructSink = getLargeStructWithIndex(slice)
+			}
+		})
+	}
+}
+
+// 要素サイズごとの値コピー（Middle）とポインタ（MiddleRef）の比較
+func BenchmarkMiddleElementSize(b *testing.B) {
+	b.Run("8B", func(b *testing.B) { benchmarkElementSize[[1]int](b) })
+	b.Run("64B", func(b *testing.B) { benchmarkElementSize[[8]int](b) })
+	b.Run("1KB", func(b *testing.B) { benchmarkElementSize[[128]int](b) })
+	b.Run("8KB", func(b *testing.B) { benchmarkElementSize[[1000]int](b) })
+}
+
+func benchmarkElementSize[T any](b *testing.B) {
+	for _, length := range []int{1000, 100000} {
+		slice := make([]T, length)
+
+		b.Run(fmt.Sprintf("Middle/len=%d", length), func(b *testing.B) {
+			b.ReportAllocs()
+			var sink T
+			for i := 0; i < b.N; i++ {
+				sink, _ = sliceaccess.Middle(slice)
+			}
+			elementSink = sink
+		})
+
+		b.Run(fmt.Sprintf("MiddleRef/len=%d", length), func(b *testing.B) {
+			b.ReportAllocs()
+			var sink *T
+			for i := 0; i < b.N; i++ {
+				sink, _ = sliceaccess.MiddleRef(slice)
+			}
+			elementSink = sink
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"slice_practice/sliceaccess"
+)
+
+// 通常のsliceと ChunkedSlice を createLargeSlice と同じデータで比較する
+var chunkedBenchSizes = []int{1000, 100000}
+
+var (
+	chunkedSink    map[string]int
+	chunkedSumSink int
+)
+
+func BenchmarkChunkedAppend(b *testing.B) {
+	for _, size := range chunkedBenchSizes {
+		data := createLargeSlice(size)
+
+		b.Run(fmt.Sprintf("slice/len=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var slice []map[string]int
+				for _, m := range data {
+					slice = append(slice, m)
+				}
+				chunkedSink = slice[len(slice)-1]
+			}
+		})
+
+		b.Run(fmt.Sprintf("chunked/len=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				chunked := sliceaccess.NewChunkedSlice[map[string]int](sliceaccess.DefaultChunkSize)
+				for _, m := range data {
+					chunked.Append(m)
+				}
+				chunkedSink = *chunked.At(chunked.Len() - 1)
+			}
+		})
+	}
+}
+
+// 先頭への追加は通常のsliceでは毎回全要素をずらすため、ChunkedSlice だけを計測する。
+// 1要素あたりの時間（ns/elem）がサイズによらずほぼ一定なら償却O(1)になっている。
+func BenchmarkChunkedPushFront(b *testing.B) {
+	for _, size := range chunkedBenchSizes {
+		data := createLargeSlice(size)
+
+		b.Run(fmt.Sprintf("chunked/len=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				chunked := sliceaccess.NewChunkedSlice[map[string]int](sliceaccess.DefaultChunkSize)
+				for _, m := range data {
+					chunked.PushFront(m)
+				}
+				chunkedSink = *chunked.At(0)
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*size), "ns/elem")
+		})
+	}
+}
+
+func BenchmarkChunkedMiddle(b *testing.B) {
+	for _, size := range chunkedBenchSizes {
+		data := createLargeSlice(size)
+		chunked := sliceaccess.NewChunkedSlice[map[string]int](sliceaccess.DefaultChunkSize)
+		for _, m := range data {
+			chunked.Append(m)
+		}
+
+		b.Run(fmt.Sprintf("slice/len=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				chunkedSink = data[len(data)/2]
+			}
+		})
+
+		b.Run(fmt.Sprintf("chunked/len=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				middle, _ := chunked.Middle()
+				chunkedSink = *middle
+			}
+		})
+	}
+}
+
+func BenchmarkChunkedIterate(b *testing.B) {
+	for _, size := range chunkedBenchSizes {
+		data := createLargeSlice(size)
+		chunked := sliceaccess.NewChunkedSlice[map[string]int](sliceaccess.DefaultChunkSize)
+		for _, m := range data {
+			chunked.Append(m)
+		}
+
+		b.Run(fmt.Sprintf("slice/len=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sum := 0
+				for _, m := range data {
+					sum += m["key1"]
+				}
+				chunkedSumSink = sum
+			}
+		})
+
+		b.Run(fmt.Sprintf("chunked/len=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sum := 0
+				for _, m := range chunked.All() {
+					sum += m["key1"]
+				}
+				chunkedSumSink = sum
+			}
+		})
+	}
+}
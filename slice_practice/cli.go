@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"slice_practice/fixture"
+	"slice_practice/i18n"
+	"slice_practice/measure"
+	"slice_practice/report"
+)
+
+// out はデモの説明文の出力先です。-output=json|csv のときは破棄し、
+// 計測結果を records に集めて最後に書き出します。
+var out io.Writer = os.Stdout
+
+// msg はデモの説明文を -lang（または LANG）の言語で書き出します
+var msg = i18n.NewPrinter(i18n.Detect(""))
+
+// records は実行中に記録した計測結果です
+var records []report.Record
+
+// record は計測結果をレコードとして記録します。results の最初の結果を比率の基準にします。
+func record(command, scenario, elemType string, sliceLen int, results ...measure.Result) {
+	baseline := results[0]
+	for _, r := range results {
+		rec := report.FromResult(r, &baseline)
+		rec.Command, rec.Scenario, rec.ElementType, rec.SliceLen = command, scenario, elemType, sliceLen
+		records = append(records, rec)
+	}
+}
+
+// recordSection は計測ループ以外の区間（sliceの作成など）をレコードとして記録します
+func recordSection(command, name, elemType string, sliceLen int, d time.Duration, m measure.MemStats) {
+	rec := report.FromSection(name, d, m)
+	rec.Command, rec.Scenario, rec.ElementType, rec.SliceLen = command, "setup", elemType, sliceLen
+	records = append(records, rec)
+}
+
+// options はサブコマンド共通のフラグです。
+// 0 や空文字のフラグは、各デモの元のデフォルト値を使うことを表します。
+type options struct {
+	size       int    // sliceのサイズ
+	iterations int    // 1回の計測でアクセスする回数
+	runs       int    // 計測の回数
+	key        string // アクセスするmapのキー
+	seed       uint64 // 計測順序のシャッフルに使うシード（0なら固定順）
+
+	// fixture は -data-seed で指定した乱数データの生成方法です（nil なら i*10 などの算術的なデータ）
+	fixture *fixture.Config
+
+	// ctx は Ctrl+C でキャンセルされ、大きなsliceの作成を中断します
+	ctx context.Context
+}
+
+// sizeOr はフラグで指定されたsliceのサイズ、指定がなければ def を返します
+func (o options) sizeOr(def int) int {
+	if o.size > 0 {
+		return o.size
+	}
+	return def
+}
+
+// measureConfig は計測の設定を返します。iterations は -iterations の指定がないときの値です。
+func (o options) measureConfig(iterations int) measure.Config {
+	cfg := measure.DefaultConfig
+	cfg.Iterations = iterations
+	if o.iterations > 0 {
+		cfg.Iterations = o.iterations
+	}
+	if o.runs > 0 {
+		cfg.Runs = o.runs
+	}
+	return cfg
+}
+
+// parseSizes は -sizes フラグの値（1000,10000,100000 など）を解釈します。空なら nil を返します。
+func parseSizes(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var sizes []int
+	for _, f := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid size %q in -sizes", f)
+		}
+		sizes = append(sizes, n)
+	}
+	return sizes, nil
+}
+
+// command は1つのサブコマンドです
+type command struct {
+	name     string
+	summary  string
+	run      func(opts options)
+	measures bool // 計測結果を records に記録するか（false なら -output=json などで出力するものがない）
+}
+
+// commands は all で実行する順に並んでいます
+var commands = []command{
+	{"access", "方法1〜3で大きなsliceの真ん中のmapにアクセス（-size, -key, -data-seed）", runAccessDemo, true},
+	{"bench", "方法1〜3のパフォーマンスを統計的に比較（-size, -key, -iterations, -runs, -seed, -sizes, -output, -lang, -baseline, -data-seed）", runPerformanceTest, true},
+	{"danger", "ポインタアクセスの危険性とハンドルによる解決策", runDangerDemo, false},
+	{"memory", "sliceの再割り当てとポインタの関係を説明", func(options) { explainMemoryManagement() }, false},
+	{"realworld", "実際の使用パターンを検証（-size, -key, -iterations, -runs, -seed, -sizes, -output, -lang, -baseline, -data-seed）", testRealWorldUsage, true},
+	{"large", "大きな要素でのパフォーマンス比較（-size, -iterations, -runs, -seed, -sizes, -output, -lang, -baseline, -data-seed）", testLargeElementsPerformance, true},
+}
+
+// run はサブコマンドを実行し、終了コードを返します。
+// サブコマンドを省略した場合はすべてのデモを順に実行します。
+func run(args []string) int {
+	name := "all"
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		name, args = args[0], args[1:]
+	}
+
+	switch name {
+	case "layout":
+		return runLayout(args)
+	case "growth":
+		return runGrowth(args)
+	case "help", "-h", "-help", "--help":
+		usage(os.Stdout)
+		return 0
+	}
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	var opts options
+	fs.IntVar(&opts.size, "size", 0, msg.T("sliceのサイズ（0なら各デモのデフォルト）"))
+	fs.IntVar(&opts.iterations, "iterations", 0, msg.T("1回の計測でアクセスする回数（0なら各デモのデフォルト）"))
+	fs.IntVar(&opts.runs, "runs", 0, msg.T("計測の回数（0ならデフォルト）"))
+	fs.StringVar(&opts.key, "key", "key1", msg.T("アクセスするmapのキー"))
+	fs.Uint64Var(&opts.seed, "seed", 0, msg.T("計測順序のシャッフルに使うシード（0なら固定順）"))
+	dataSeed := fs.Uint64("data-seed", 0, msg.T("データ生成の乱数のシード（0なら i*10 などの算術的なデータ）"))
+	keys := fs.String("keys", "", msg.T("生成するmapのカンマ区切りのキー（-data-seed 用、省略時は key1,key2,key3）"))
+	dist := fs.String("dist", "", msg.T("生成する値の分布（-data-seed 用、uniform[:lo,hi], normal[:mean,stddev], exp[:mean]）"))
+	nilProb := fs.Float64("nil-prob", 0, msg.T("要素のmapを nil にする確率（-data-seed 用）"))
+	missingProb := fs.Float64("missing-prob", 0, msg.T("mapからキーを欠けさせる確率（-data-seed 用）"))
+	sizes := fs.String("sizes", "", msg.T("カンマ区切りのsliceのサイズ。サイズごとに繰り返し計測する（-size より優先）"))
+	output := fs.String("output", "text", msg.T("出力形式（text, json, csv, html）"))
+	lang := fs.String("lang", "", msg.T("表示言語（ja, en）。省略時は LANG から判定"))
+	var bo baselineOptions
+	fs.StringVar(&bo.path, "baseline", "", msg.T("比較するベースラインファイル（空なら比較しない）"))
+	fs.BoolVar(&bo.save, "save-baseline", false, msg.T("今回の計測結果を -baseline のファイルに保存する"))
+	fs.Float64Var(&bo.threshold, "threshold", 10, msg.T("リグレッションとみなす変化率（%）"))
+	fs.Usage = func() {
+		usage(fs.Output())
+		msg.Fprintln(fs.Output(), "\nフラグ:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if !setLang(*lang) {
+		return 2
+	}
+
+	sizeList, err := parseSizes(*sizes)
+	if err != nil {
+		msg.Fprintf(os.Stderr, "エラー: %v\n", err)
+		return 2
+	}
+
+	if *dataSeed != 0 {
+		cfg := &fixture.Config{Seed: *dataSeed, NilMapProb: *nilProb, MissingKeyProb: *missingProb}
+		if *keys != "" {
+			for _, k := range strings.Split(*keys, ",") {
+				cfg.Keys = append(cfg.Keys, strings.TrimSpace(k))
+			}
+		}
+		if *dist != "" {
+			cfg.Values, err = fixture.ParseDistribution(*dist)
+		}
+		if err == nil {
+			err = cfg.Validate()
+		}
+		if err != nil {
+			msg.Fprintf(os.Stderr, "エラー: %v\n", err)
+			return 2
+		}
+		opts.fixture = cfg
+	} else if *keys != "" || *dist != "" || *nilProb != 0 || *missingProb != 0 {
+		msg.Fprintln(os.Stderr, "エラー: -keys, -dist, -nil-prob, -missing-prob には -data-seed が必要です")
+		return 2
+	}
+
+	format, err := report.ParseFormat(*output)
+	if err != nil {
+		msg.Fprintf(os.Stderr, "エラー: %v\n", err)
+		return 2
+	}
+	if bo.save && bo.path == "" {
+		msg.Fprintln(os.Stderr, "エラー: -save-baseline には -baseline が必要です")
+		return 2
+	}
+
+	var selected []command
+	measures := false
+	for _, c := range commands {
+		if name == "all" || c.name == name {
+			selected = append(selected, c)
+			measures = measures || c.measures
+		}
+	}
+	if len(selected) > 0 && format != report.Text {
+		if !measures {
+			// 説明文を捨てると何も出力されないので、テキスト以外の形式は受け付けない
+			msg.Fprintf(os.Stderr, "エラー: %s は計測結果を記録しないため -output=%s は使えません（text のみ）\n", name, *output)
+			return 2
+		}
+		out = io.Discard
+	}
+	if len(selected) > 0 {
+		if sizeList == nil {
+			sizeList = []int{opts.size}
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		opts.ctx = ctx
+	sizes:
+		for _, n := range sizeList {
+			opts.size = n
+			for _, c := range selected {
+				c.run(opts)
+				if ctx.Err() != nil {
+					break sizes
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			msg.Fprintln(os.Stderr, "中断しました")
+			return 130
+		}
+		if format == report.HTML {
+			err = report.WriteHTML(os.Stdout, records, msg.T)
+		} else {
+			err = report.Write(os.Stdout, format, records)
+		}
+		if err != nil {
+			msg.Fprintf(os.Stderr, "エラー: %v\n", err)
+			return 1
+		}
+		if bo.path != "" {
+			// JSON や CSV の出力を壊さないよう、比較結果はテキストのときだけ標準出力に書く
+			var w io.Writer = os.Stdout
+			if format != report.Text {
+				w = os.Stderr
+			}
+			return checkBaseline(w, bo, records)
+		}
+		return 0
+	}
+
+	msg.Fprintf(os.Stderr, "不明なサブコマンド: %s\n\n", name)
+	usage(os.Stderr)
+	return 2
+}
+
+// setLang は -lang の値を検証して msg の言語を切り替えます。
+// 不明な言語の場合はエラーを表示して false を返します。
+func setLang(lang string) bool {
+	if _, ok := i18n.ParseLang(lang); lang != "" && !ok {
+		msg.Fprintf(os.Stderr, "エラー: 不明な言語 %q（ja または en）\n", lang)
+		return false
+	}
+	msg = i18n.NewPrinter(i18n.Detect(lang))
+	return true
+}
+
+// usage はサブコマンドの一覧を書き出します
+func usage(w io.Writer) {
+	msg.Fprintln(w, "使い方: slice_practice [サブコマンド] [フラグ]")
+	msg.Fprintln(w, "\nサブコマンド:")
+	fmt.Fprintf(w, "  %-10s %s\n", "all", msg.T("すべてのデモを順に実行（省略時）"))
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, msg.T(c.summary))
+	}
+	fmt.Fprintf(w, "  %-10s %s\n", "layout", msg.T("構造体のフィールド配置を表示（layout [-goarch=arch] <pkg>.<Type>）"))
+	fmt.Fprintf(w, "  %-10s %s\n", "growth", msg.T("要素サイズごとのsliceの容量の伸び方を表示・予測（growth [-elemsize=bytes] [-n=count] [-cap=n] [-go=version]）"))
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"slice_practice/i18n"
+)
+
+// msgArg は msg のメソッドごとの、翻訳する文字列の引数の位置です
+var msgArg = map[string]int{"T": 0, "Sprintf": 0, "Fprintf": 1, "Fprintln": 1}
+
+// main パッケージで msg に渡している文字列リテラルに、すべて英語の翻訳があることを確認する。
+// 翻訳がないと -lang=en でも日本語のまま表示される。
+func TestMessagesHaveEnglishTranslation(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") || name == "messages_en.go" {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			recv, ok := sel.X.(*ast.Ident)
+			i, known := msgArg[sel.Sel.Name]
+			if !ok || recv.Name != "msg" || !known || len(call.Args) <= i {
+				return true
+			}
+			lit, ok := call.Args[i].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			s, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if key := strings.TrimSpace(s); key != "" {
+				if _, ok := i18n.Lookup(i18n.En, key); !ok {
+					t.Errorf("%s: no English translation for %q", fset.Position(lit.Pos()), key)
+				}
+			}
+			return true
+		})
+	}
+
+	// サブコマンドの説明は usage で msg.T(c.summary) として翻訳する
+	for _, c := range commands {
+		if _, ok := i18n.Lookup(i18n.En, c.summary); !ok {
+			t.Errorf("command %s: no English translation for %q", c.name, c.summary)
+		}
+	}
+}
+
+// ranCommand は runCLI で実行されたサブコマンドです
+type ranCommand struct {
+	name string
+	opts options
+}
+
+// cliResult は runCLI の結果です
+type cliResult struct {
+	code           int
+	ran            []ranCommand
+	stdout, stderr string
+}
+
+// runCLI は各サブコマンドを実行内容の記録だけに置き換えて run(args) を呼びます。
+// run が書き換えるパッケージ変数（out, msg, records）と標準出力・標準エラー出力は元に戻します。
+func runCLI(t *testing.T, args ...string) cliResult {
+	t.Helper()
+	var res cliResult
+	saved, savedOut, savedMsg, savedRecords := commands, out, msg, records
+	commands = nil
+	for _, c := range saved {
+		name := c.name
+		c.run = func(opts options) {
+			opts.ctx = nil // 比較しやすいよう、run が作るcontextは記録しない
+			res.ran = append(res.ran, ranCommand{name, opts})
+		}
+		commands = append(commands, c)
+	}
+	dir := t.TempDir()
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout = createFile(t, filepath.Join(dir, "stdout"))
+	os.Stderr = createFile(t, filepath.Join(dir, "stderr"))
+	defer func() {
+		os.Stdout.Close()
+		os.Stderr.Close()
+		os.Stdout, os.Stderr = stdout, stderr
+		commands, out, msg, records = saved, savedOut, savedMsg, savedRecords
+	}()
+
+	res.code = run(args)
+	res.stdout = readFile(t, filepath.Join(dir, "stdout"))
+	res.stderr = readFile(t, filepath.Join(dir, "stderr"))
+	return res
+}
+
+func createFile(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func readFile(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+// names は実行されたサブコマンドの名前を実行順に返します
+func (r cliResult) names() []string {
+	var names []string
+	for _, c := range r.ran {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+func TestRunDispatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantRan  []string
+	}{
+		{"all by default", nil, 0, []string{"access", "bench", "danger", "memory", "realworld", "large"}},
+		{"explicit all", []string{"all"}, 0, []string{"access", "bench", "danger", "memory", "realworld", "large"}},
+		{"one subcommand", []string{"bench"}, 0, []string{"bench"}},
+		{"flags without subcommand", []string{"-size=10"}, 0, []string{"access", "bench", "danger", "memory", "realworld", "large"}},
+		{"sizes repeat the subcommand", []string{"access", "-sizes=10,20"}, 0, []string{"access", "access"}},
+		{"help", []string{"help"}, 0, nil},
+		{"-h", []string{"bench", "-h"}, 0, nil},
+		{"unknown subcommand", []string{"nosuch"}, 2, nil},
+		{"unknown flag", []string{"bench", "-nosuch"}, 2, nil},
+		{"invalid sizes", []string{"bench", "-sizes=10,x"}, 2, nil},
+		{"unknown language", []string{"bench", "-lang=xx"}, 2, nil},
+		{"save-baseline without baseline", []string{"bench", "-save-baseline"}, 2, nil},
+		{"layout help", []string{"layout", "-h"}, 0, nil},
+		{"layout unknown flag", []string{"layout", "-nosuch", "slice_practice.LargeStruct"}, 2, nil},
+		{"layout unknown language", []string{"layout", "-lang=xx", "slice_practice.LargeStruct"}, 2, nil},
+		{"layout without type", []string{"layout"}, 2, nil},
+		{"growth help", []string{"growth", "-h"}, 0, nil},
+		{"growth invalid number", []string{"growth", "-n=x"}, 2, nil},
+		{"growth unknown language", []string{"growth", "-lang=xx"}, 2, nil},
+		{"growth extra argument", []string{"growth", "extra"}, 2, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := runCLI(t, tt.args...)
+			if res.code != tt.wantCode || !reflect.DeepEqual(res.names(), tt.wantRan) {
+				t.Errorf("run(%q) = %d, ran %v; want %d, ran %v (stderr: %s)",
+					tt.args, res.code, res.names(), tt.wantCode, tt.wantRan, res.stderr)
+			}
+		})
+	}
+}
+
+func TestRunOptions(t *testing.T) {
+	res := runCLI(t, "realworld", "-size=100", "-iterations=5", "-runs=3", "-key=k", "-seed=7")
+	if res.code != 0 || len(res.ran) != 1 {
+		t.Fatalf("code = %d, ran %v", res.code, res.names())
+	}
+	want := options{size: 100, iterations: 5, runs: 3, key: "k", seed: 7}
+	if got := res.ran[0].opts; !reflect.DeepEqual(got, want) {
+		t.Errorf("opts = %+v; want %+v", got, want)
+	}
+
+	res = runCLI(t, "access", "-sizes=10,20")
+	if len(res.ran) != 2 || res.ran[0].opts.size != 10 || res.ran[1].opts.size != 20 {
+		t.Errorf("-sizes=10,20 ran %+v", res.ran)
+	}
+}
+
+// -keys などのデータ生成のフラグには -data-seed が必要で、指定した値は検証される
+func TestRunDataSeed(t *testing.T) {
+	for _, args := range [][]string{
+		{"-keys=a,b"},
+		{"-dist=exp"},
+		{"-nil-prob=0.1"},
+		{"-missing-prob=0.1"},
+		{"-data-seed=1", "-keys=a,,b"},
+		{"-data-seed=1", "-dist=zipf"},
+		{"-data-seed=1", "-nil-prob=2"},
+		{"-data-seed=1", "-missing-prob=-1"},
+	} {
+		res := runCLI(t, append([]string{"access", "-lang=ja"}, args...)...)
+		if res.code != 2 || len(res.ran) != 0 {
+			t.Errorf("run(%q) = %d, ran %v; want 2 without running", args, res.code, res.names())
+		}
+		if !strings.Contains(res.stderr, "エラー") {
+			t.Errorf("run(%q) printed no error: %q", args, res.stderr)
+		}
+	}
+
+	res := runCLI(t, "access", "-data-seed=1")
+	if res.code != 0 || res.ran[0].opts.fixture == nil || res.ran[0].opts.fixture.Seed != 1 {
+		t.Fatalf("-data-seed=1: code %d, ran %+v", res.code, res.ran)
+	}
+	if res := runCLI(t, "access"); res.ran[0].opts.fixture != nil {
+		t.Errorf("fixture without -data-seed = %+v; want nil", res.ran[0].opts.fixture)
+	}
+
+	res = runCLI(t, "access", "-data-seed=3", "-keys=a, b", "-dist=exp:5", "-nil-prob=0.25", "-missing-prob=0.5")
+	if res.code != 0 {
+		t.Fatalf("code = %d (stderr: %s)", res.code, res.stderr)
+	}
+	cfg := res.ran[0].opts.fixture
+	if cfg.Seed != 3 || !reflect.DeepEqual(cfg.Keys, []string{"a", "b"}) || cfg.Values.String() != "exp:5" ||
+		cfg.NilMapProb != 0.25 || cfg.MissingKeyProb != 0.5 {
+		t.Errorf("fixture = %+v", cfg)
+	}
+}
+
+func TestRunOutput(t *testing.T) {
+	tests := []struct {
+		output     string
+		wantCode   int
+		wantStdout string // 標準出力の先頭（記録がないときの出力）
+	}{
+		{"text", 0, ""},
+		{"json", 0, "[]"},
+		{"csv", 0, "command,scenario,"},
+		{"html", 0, "<!DOCTYPE html>"},
+		{"xml", 2, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			res := runCLI(t, "bench", "-output="+tt.output)
+			if res.code != tt.wantCode {
+				t.Fatalf("code = %d; want %d (stderr: %s)", res.code, tt.wantCode, res.stderr)
+			}
+			if tt.wantCode != 0 {
+				if len(res.ran) != 0 {
+					t.Errorf("ran %v with an invalid -output", res.names())
+				}
+				return
+			}
+			if !strings.HasPrefix(res.stdout, tt.wantStdout) {
+				t.Errorf("stdout = %q; want prefix %q", res.stdout, tt.wantStdout)
+			}
+		})
+	}
+}
+
+// 計測しないデモだけを選んだ場合、テキスト以外の形式では何も出力できないのでエラーにする
+func TestRunOutputWithoutMeasurements(t *testing.T) {
+	for _, args := range [][]string{
+		{"danger", "-output=json"},
+		{"memory", "-output=csv"},
+		{"danger", "-output=html"},
+	} {
+		res := runCLI(t, args...)
+		if res.code != 2 || len(res.ran) != 0 || res.stderr == "" {
+			t.Errorf("run(%q) = %d, ran %v, stderr %q; want 2 with an error", args, res.code, res.names(), res.stderr)
+		}
+	}
+	for _, args := range [][]string{
+		{"danger", "-output=text"},
+		{"all", "-output=json"},
+		{"-output=csv"},
+	} {
+		if res := runCLI(t, args...); res.code != 0 || len(res.ran) == 0 {
+			t.Errorf("run(%q) = %d, ran %v; want 0 (stderr: %s)", args, res.code, res.names(), res.stderr)
+		}
+	}
+}
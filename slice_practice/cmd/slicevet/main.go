@@ -0,0 +1,17 @@
+// slicevet はslice_practiceのアナライザーをまとめたコマンドです。
+// 単体でも、go vet -vettool=$(which slicevet) としても実行できます。
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"slice_practice/analysis/appendptr"
+	"slice_practice/analysis/largecopy"
+)
+
+func main() {
+	multichecker.Main(
+		appendptr.Analyzer,
+		largecopy.Analyzer,
+	)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"slice_practice/sliceaccess"
+)
+
+// 並行に実行するベンチマークの結果の書き込み先（-race でも競合しないよう atomic にする）
+var concurrentSink atomic.Int64
+
+// ConcurrentSliceOfMaps と同期なしのアクセス（方法2）の比較。
+// 同期なしのアクセスは書き込みと同時に使えないため、読み込みのみで比較する。
+func BenchmarkConcurrentMiddleMap(b *testing.B) {
+	const length = 100000
+	slice := createLargeSlice(length)
+
+	b.Run("unsynchronized/read", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			sum := 0
+			for pb.Next() {
+				v, _ := efficientAccessMiddleMap(slice, "key1")
+				sum += v
+			}
+			concurrentSink.Add(int64(sum))
+		})
+	})
+
+	// shards=1 は sync.RWMutex 1つで全体を守る場合に相当する
+	for _, shards := range []int{1, sliceaccess.DefaultShards, length} {
+		c := sliceaccess.NewConcurrentSliceOfMaps(createLargeSlice(length), shards)
+
+		b.Run(fmt.Sprintf("shards=%d/read", shards), func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				sum := 0
+				for pb.Next() {
+					v, _ := c.Middle("key1")
+					sum += v
+				}
+				concurrentSink.Add(int64(sum))
+			})
+		})
+
+		// 10回に1回、ランダムではなく順に別の要素へ書き込む
+		b.Run(fmt.Sprintf("shards=%d/read+10%%write", shards), func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				sum, i := 0, 0
+				for pb.Next() {
+					i++
+					if i%10 == 0 {
+						c.Update(i%length, func(m map[string]int) { m["key1"]++ })
+						continue
+					}
+					v, _ := c.Get(i%length, "key1")
+					sum += v
+				}
+				concurrentSink.Add(int64(sum))
+			})
+		})
+	}
+}
+
+// readContainer はベンチマークで比較する並行安全なコンテナです
+type readContainer interface {
+	Middle(key string) (int, error)
+	Set(i int, key string, value int) error
+}
+
+// 書き込みが続いている間の読み込みのスループットを、GOMAXPROCS ごとに比較する。
+// 1つのgoroutineが一定の頻度で書き込み、すべての P で Middle を読む。
+func BenchmarkSnapshotMiddleMap(b *testing.B) {
+	const (
+		length    = 10000
+		writeRate = 10000
+	)
+	containers := []struct {
+		name string
+		new  func() readContainer
+	}{
+		{"snapshot", func() readContainer { return sliceaccess.NewSnapshotSliceOfMaps(createLargeSlice(length)) }},
+		{"sharded", func() readContainer {
+			return sliceaccess.NewConcurrentSliceOfMaps(createLargeSlice(length), sliceaccess.DefaultShards)
+		}},
+		{"rwmutex", func() readContainer { return sliceaccess.NewConcurrentSliceOfMaps(createLargeSlice(length), 1) }},
+	}
+
+	for _, procs := range []int{1, 2, 4, 8} {
+		for _, ct := range containers {
+			b.Run(fmt.Sprintf("procs=%d/%s", procs, ct.name), func(b *testing.B) {
+				defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+				c := ct.new()
+
+				var writes atomic.Int64
+				stop := make(chan struct{})
+				done := make(chan struct{})
+				go func() {
+					defer close(done)
+					// コンテナによらず同じ頻度で書き込む（最大 writeRate 回/秒）
+					ticker := time.NewTicker(time.Second / writeRate)
+					defer ticker.Stop()
+					for i := 0; ; i++ {
+						select {
+						case <-stop:
+							return
+						case <-ticker.C:
+						}
+						c.Set(length/2, "key1", i)
+						writes.Add(1)
+					}
+				}()
+
+				b.ResetTimer()
+				start := time.Now()
+				b.RunParallel(func(pb *testing.PB) {
+					sum := 0
+					for pb.Next() {
+						v, _ := c.Middle("key1")
+						sum += v
+					}
+					concurrentSink.Add(int64(sum))
+				})
+				elapsed := time.Since(start)
+				b.StopTimer()
+				close(stop)
+				<-done
+
+				b.ReportMetric(float64(b.N)/elapsed.Seconds(), "reads/s")
+				b.ReportMetric(float64(writes.Load())/elapsed.Seconds(), "writes/s")
+			})
+		}
+	}
+}
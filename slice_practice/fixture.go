@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"slice_practice/fixture"
+)
+
+// fixtureCheckInterval は並列版の作成中に ctx のキャンセルを確認する間隔（要素数）です
+const fixtureCheckInterval = 4096
+
+// buildParallel は [0, size) を GOMAXPROCS 個の連続した範囲に分け、範囲ごとに1つのgoroutineで
+// fill(i) を呼びます。fill は要素 i だけを書き込むので、結果は実行順によらず逐次版と同じになります。
+// ctx がキャンセルされると各goroutineは fixtureCheckInterval 個ごとの確認で止まり、
+// 途中で止まった場合は ctx.Err() を返します。
+func buildParallel(ctx context.Context, size int, fill func(i int)) error {
+	workers := min(runtime.GOMAXPROCS(0), size)
+	var stopped atomic.Bool
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := range workers {
+		lo, hi := size*w/workers, size*(w+1)/workers
+		go func() {
+			defer wg.Done()
+			for start := lo; start < hi; start += fixtureCheckInterval {
+				if ctx.Err() != nil {
+					stopped.Store(true)
+					return
+				}
+				for i := start; i < min(start+fixtureCheckInterval, hi); i++ {
+					fill(i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if stopped.Load() {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// createLargeSliceParallel は createLargeSlice の並列版です。
+// 結果は createLargeSlice(size) と同じで、キャンセルされた場合は nil と ctx.Err() を返します。
+func createLargeSliceParallel(ctx context.Context, size int) ([]map[string]int, error) {
+	slice := make([]map[string]int, size)
+	err := buildParallel(ctx, size, func(i int) {
+		slice[i] = newLargeSliceElem(i)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slice, nil
+}
+
+// createLargeStructSliceParallel は createLargeStructSlice の並列版です。
+// 結果は createLargeStructSlice(size) と同じで（Metadata の "created" は全要素で共通）、
+// キャンセルされた場合は nil と ctx.Err() を返します。
+func createLargeStructSliceParallel(ctx context.Context, size int) ([]LargeStruct, error) {
+	slice := make([]LargeStruct, size)
+	created := time.Now()
+	err := buildParallel(ctx, size, func(i int) {
+		fillLargeStruct(&slice[i], i, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slice, nil
+}
+
+// largeSlice は -data-seed などの指定に従い、大きなsliceを並列に作成します。
+// 指定がなければ createLargeSlice と同じ算術的なデータ、あれば opts.fixture で生成したデータです。
+func (o options) largeSlice(size int) ([]map[string]int, error) {
+	if o.fixture == nil {
+		return createLargeSliceParallel(o.ctx, size)
+	}
+	slice := make([]map[string]int, size)
+	err := buildParallel(o.ctx, size, func(i int) {
+		slice[i] = o.fixture.Map(i)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slice, nil
+}
+
+// largeStructSlice は largeSlice の LargeStruct 版です
+func (o options) largeStructSlice(size int) ([]LargeStruct, error) {
+	if o.fixture == nil {
+		return createLargeStructSliceParallel(o.ctx, size)
+	}
+	slice := make([]LargeStruct, size)
+	created := time.Now()
+	err := buildParallel(o.ctx, size, func(i int) {
+		fillRandomLargeStruct(&slice[i], i, created, o.fixture)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slice, nil
+}
+
+// fillRandomLargeStruct は fillLargeStruct の i*j の代わりに cfg の分布から値を引きます。
+// Metadata は cfg.NilMapProb の確率で nil になり、各キーは cfg.MissingKeyProb の確率で欠けます。
+func fillRandomLargeStruct(s *LargeStruct, i int, created time.Time, cfg *fixture.Config) {
+	r := cfg.Rand(i)
+	s.ID = i
+	s.Name = fmt.Sprintf("Item_%d", i)
+	if !cfg.IsNil(r) {
+		// 乱数を引く順番を固定するため、mapではなく配列の順に処理する
+		entries := [...]struct {
+			key   string
+			value interface{}
+		}{
+			{"created", created},
+			{"version", cfg.Value(r) % 10},
+			{"active", cfg.Value(r)%2 == 0},
+		}
+		s.Metadata = make(map[string]interface{}, len(entries))
+		for _, e := range entries {
+			if !cfg.IsMissing(r) {
+				s.Metadata[e.key] = e.value
+			}
+		}
+	}
+	s.Values = make([]float64, 100)
+
+	for j := range s.Data {
+		s.Data[j] = cfg.Value(r)
+	}
+	for j := range s.Values {
+		s.Values[j] = float64(cfg.Value(r)) * 0.1
+	}
+}
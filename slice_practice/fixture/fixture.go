@@ -0,0 +1,214 @@
+// Package fixture は、シード付きの疑似乱数（math/rand/v2 の PCG）でデモ用のデータを生成します。
+//
+// createLargeSlice の i*10 のような算術的なデータでは、値からインデックスが分かり、
+// nilのmapや存在しないキーも現れないため、アクセス関数のエラー処理が一度も通りません。
+// Config はキーの集合、値の分布、nilのmapとキーの欠落の確率を指定してデータを作ります。
+//
+// 要素 i の乱数列は Seed と i だけから決まるので、どの順番・並列度で作っても結果は同じです。
+package fixture
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// DefaultKeys は Config.Keys が空のときに使うキーです（createLargeSlice と同じ）
+var DefaultKeys = []string{"key1", "key2", "key3"}
+
+// DefaultValues は Config.Values が nil のときに使う値の分布です
+var DefaultValues = Uniform(0, 1000)
+
+// Config はデータの生成方法です
+type Config struct {
+	Seed           uint64
+	Keys           []string     // 各mapに入れるキー（空なら DefaultKeys）
+	Values         Distribution // 値の分布（nil なら DefaultValues）
+	NilMapProb     float64      // 要素のmapを nil にする確率
+	MissingKeyProb float64      // キーごとに、そのキーをmapに入れない確率
+}
+
+// Validate は確率が [0, 1] の範囲にあり、キーに空文字列がないことを確認します
+func (c *Config) Validate() error {
+	for _, key := range c.Keys {
+		if key == "" {
+			return fmt.Errorf("empty key in %q", strings.Join(c.Keys, ","))
+		}
+	}
+	if !(c.NilMapProb >= 0 && c.NilMapProb <= 1) {
+		return fmt.Errorf("nil map probability %v is not in [0, 1]", c.NilMapProb)
+	}
+	if !(c.MissingKeyProb >= 0 && c.MissingKeyProb <= 1) {
+		return fmt.Errorf("missing key probability %v is not in [0, 1]", c.MissingKeyProb)
+	}
+	return nil
+}
+
+// Rand は要素 i 用の乱数生成器を返します
+func (c *Config) Rand(i int) *rand.Rand {
+	return rand.New(rand.NewPCG(c.Seed, uint64(i)))
+}
+
+// Value は値の分布から1つの値を引きます
+func (c *Config) Value(r *rand.Rand) int {
+	if c.Values == nil {
+		return DefaultValues.Int(r)
+	}
+	return c.Values.Int(r)
+}
+
+// IsNil は要素のmapを nil にするかどうかを NilMapProb の確率で決めます
+func (c *Config) IsNil(r *rand.Rand) bool {
+	return c.NilMapProb > 0 && r.Float64() < c.NilMapProb
+}
+
+// IsMissing はキーをmapに入れないかどうかを MissingKeyProb の確率で決めます
+func (c *Config) IsMissing(r *rand.Rand) bool {
+	return c.MissingKeyProb > 0 && r.Float64() < c.MissingKeyProb
+}
+
+// Map は要素 i のmapを生成します。NilMapProb の確率で nil を返します。
+func (c *Config) Map(i int) map[string]int {
+	r := c.Rand(i)
+	if c.IsNil(r) {
+		return nil
+	}
+	keys := c.Keys
+	if len(keys) == 0 {
+		keys = DefaultKeys
+	}
+	m := make(map[string]int, len(keys))
+	for _, key := range keys {
+		if c.IsMissing(r) {
+			continue
+		}
+		m[key] = c.Value(r)
+	}
+	return m
+}
+
+// Maps は要素数 n のmapのsliceを生成します
+func (c *Config) Maps(n int) []map[string]int {
+	slice := make([]map[string]int, n)
+	for i := range slice {
+		slice[i] = c.Map(i)
+	}
+	return slice
+}
+
+// Distribution は値の分布です
+type Distribution interface {
+	Int(r *rand.Rand) int
+	String() string
+}
+
+// Uniform は [lo, hi) の整数の一様分布を返します。lo < hi で、hi-lo が int に収まらなければなりません。
+func Uniform(lo, hi int) Distribution {
+	return uniform{lo, hi}
+}
+
+type uniform struct{ lo, hi int }
+
+func (d uniform) Int(r *rand.Rand) int { return d.lo + r.IntN(d.hi-d.lo) }
+func (d uniform) String() string       { return fmt.Sprintf("uniform:%d,%d", d.lo, d.hi) }
+
+// Normal は平均 mean、標準偏差 stddev の正規分布を（整数に丸めて）返します
+func Normal(mean, stddev float64) Distribution {
+	return normal{mean, stddev}
+}
+
+type normal struct{ mean, stddev float64 }
+
+func (d normal) Int(r *rand.Rand) int { return int(math.Round(r.NormFloat64()*d.stddev + d.mean)) }
+func (d normal) String() string       { return fmt.Sprintf("normal:%g,%g", d.mean, d.stddev) }
+
+// Exponential は平均 mean の指数分布を（整数に丸めて）返します。小さい値ほど多く現れます。
+func Exponential(mean float64) Distribution {
+	return exponential{mean}
+}
+
+type exponential struct{ mean float64 }
+
+func (d exponential) Int(r *rand.Rand) int { return int(math.Round(r.ExpFloat64() * d.mean)) }
+func (d exponential) String() string       { return fmt.Sprintf("exp:%g", d.mean) }
+
+// ParseDistribution は "uniform:0,1000"、"normal:500,100"、"exp:100" の形式の分布を解釈します。
+// パラメータを省略すると uniform は 0,1000、normal は 500,100、exp は 100 を使います。
+// uniform の範囲は整数で lo < hi（幅が int に収まること）、normal の標準偏差は0以上、exp の平均は正でなければなりません。
+func ParseDistribution(s string) (Distribution, error) {
+	name, params, _ := strings.Cut(s, ":")
+	var args []string
+	if params != "" {
+		args = strings.Split(params, ",")
+		for i := range args {
+			args[i] = strings.TrimSpace(args[i])
+		}
+	}
+	switch name {
+	case "uniform":
+		switch len(args) {
+		case 0:
+			return DefaultValues, nil
+		case 2:
+			lo, err1 := strconv.Atoi(args[0])
+			hi, err2 := strconv.Atoi(args[1])
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("uniform distribution %q needs integer bounds", s)
+			}
+			if lo >= hi {
+				return nil, fmt.Errorf("uniform distribution %q needs lo < hi", s)
+			}
+			if hi-lo < 0 {
+				// 幅が int に収まらないと r.IntN に負の値を渡してしまう
+				return nil, fmt.Errorf("uniform distribution %q is wider than the int range", s)
+			}
+			return Uniform(lo, hi), nil
+		}
+	case "normal":
+		switch len(args) {
+		case 0:
+			return Normal(500, 100), nil
+		case 2:
+			v, err := parseFloats(s, args)
+			if err != nil {
+				return nil, err
+			}
+			if v[1] < 0 {
+				return nil, fmt.Errorf("normal distribution %q needs a non-negative stddev", s)
+			}
+			return Normal(v[0], v[1]), nil
+		}
+	case "exp":
+		switch len(args) {
+		case 0:
+			return Exponential(100), nil
+		case 1:
+			v, err := parseFloats(s, args)
+			if err != nil {
+				return nil, err
+			}
+			if v[0] <= 0 {
+				return nil, fmt.Errorf("exponential distribution %q needs a positive mean", s)
+			}
+			return Exponential(v[0]), nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown distribution %q (uniform, normal, exp)", name)
+	}
+	return nil, fmt.Errorf("wrong number of parameters in distribution %q", s)
+}
+
+// parseFloats は分布 s のパラメータ args を有限の浮動小数点数として解釈します
+func parseFloats(s string, args []string) ([]float64, error) {
+	v := make([]float64, len(args))
+	for i, arg := range args {
+		f, err := strconv.ParseFloat(arg, 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, fmt.Errorf("invalid parameter %q in distribution %q", arg, s)
+		}
+		v[i] = f
+	}
+	return v, nil
+}
@@ -0,0 +1,143 @@
+package fixture
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMapsDeterministic(t *testing.T) {
+	c := Config{Seed: 42, NilMapProb: 0.1, MissingKeyProb: 0.2}
+	a, b := c.Maps(1000), c.Maps(1000)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatal("same seed produced different data")
+	}
+	// 要素 i は他の要素を作る順番によらない
+	if m := c.Map(500); !reflect.DeepEqual(m, a[500]) {
+		t.Errorf("Map(500) = %v; want %v", m, a[500])
+	}
+	other := Config{Seed: 43, NilMapProb: 0.1, MissingKeyProb: 0.2}
+	if reflect.DeepEqual(a, other.Maps(1000)) {
+		t.Error("different seeds produced the same data")
+	}
+}
+
+func TestMapsProbabilities(t *testing.T) {
+	const n = 20000
+	c := Config{Seed: 1, Keys: []string{"a", "b"}, NilMapProb: 0.25, MissingKeyProb: 0.5}
+	nils, keys := 0, 0
+	for _, m := range c.Maps(n) {
+		if m == nil {
+			nils++
+			continue
+		}
+		for k := range m {
+			if k != "a" && k != "b" {
+				t.Fatalf("unexpected key %q", k)
+			}
+		}
+		keys += len(m)
+	}
+	if got := float64(nils) / n; math.Abs(got-0.25) > 0.02 {
+		t.Errorf("nil map ratio = %.3f; want about 0.25", got)
+	}
+	if got := float64(keys) / float64(2*(n-nils)); math.Abs(got-0.5) > 0.02 {
+		t.Errorf("present key ratio = %.3f; want about 0.5", got)
+	}
+
+	for _, m := range (&Config{Seed: 1, NilMapProb: 1}).Maps(10) {
+		if m != nil {
+			t.Fatal("NilMapProb=1 produced a non-nil map")
+		}
+	}
+	for _, m := range (&Config{Seed: 1}).Maps(10) {
+		if len(m) != len(DefaultKeys) {
+			t.Fatalf("default config produced %v", m)
+		}
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	c := Config{Seed: 7}
+	r := c.Rand(0)
+	for range 1000 {
+		if v := Uniform(10, 20).Int(r); v < 10 || v >= 20 {
+			t.Fatalf("Uniform(10, 20) = %d", v)
+		}
+		if v := Exponential(5).Int(r); v < 0 {
+			t.Fatalf("Exponential(5) = %d", v)
+		}
+	}
+
+	sum := 0
+	for range 10000 {
+		sum += Normal(500, 10).Int(r)
+	}
+	if mean := float64(sum) / 10000; math.Abs(mean-500) > 1 {
+		t.Errorf("Normal(500, 10) mean = %v", mean)
+	}
+}
+
+func TestParseDistribution(t *testing.T) {
+	for s, want := range map[string]string{
+		"uniform":       "uniform:0,1000",
+		"uniform:5,10":  "uniform:5,10",
+		"normal":        "normal:500,100",
+		"normal:0, 2.5": "normal:0,2.5",
+		"exp:30":        "exp:30",
+	} {
+		d, err := ParseDistribution(s)
+		if err != nil || d.String() != want {
+			t.Errorf("ParseDistribution(%q) = %v, %v; want %s", s, d, err, want)
+		}
+	}
+	for _, s := range []string{
+		"", "zipf", "uniform:1", "uniform:5,5", "uniform:0.2,0.8", "uniform:0.5,10", "uniform:0,1e3",
+		"exp:x", "exp:0", "exp:-5", "exp:Inf", "normal:1,2,3", "normal:500,-1", "normal:NaN,1",
+	} {
+		if _, err := ParseDistribution(s); err == nil {
+			t.Errorf("ParseDistribution(%q) returned no error", s)
+		}
+	}
+}
+
+// 幅が int に収まらない一様分布は拒否し、収まる範囲なら端の値でも値を引ける
+func TestParseDistributionUniformWidth(t *testing.T) {
+	for _, bounds := range [][2]int{{math.MinInt, math.MaxInt}, {-1, math.MaxInt}, {math.MinInt, 0}} {
+		s := fmt.Sprintf("uniform:%d,%d", bounds[0], bounds[1])
+		if _, err := ParseDistribution(s); err == nil {
+			t.Errorf("ParseDistribution(%q) returned no error", s)
+		}
+	}
+	for _, bounds := range [][2]int{{0, math.MaxInt}, {math.MinInt, -1}, {math.MinInt / 2, math.MaxInt / 2}} {
+		s := fmt.Sprintf("uniform:%d,%d", bounds[0], bounds[1])
+		d, err := ParseDistribution(s)
+		if err != nil {
+			t.Errorf("ParseDistribution(%q) = %v", s, err)
+			continue
+		}
+		r := (&Config{Seed: 1}).Rand(0)
+		for range 100 {
+			if v := d.Int(r); v < bounds[0] || v >= bounds[1] {
+				t.Fatalf("%s drew %d", s, v)
+			}
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, c := range []Config{{NilMapProb: -0.1}, {MissingKeyProb: 1.5}, {NilMapProb: math.NaN()}} {
+		if c.Validate() == nil {
+			t.Errorf("Validate(%+v) returned no error", c)
+		}
+	}
+	for _, keys := range [][]string{{"a", "", "b"}, {"a", ""}} {
+		if (&Config{Keys: keys}).Validate() == nil {
+			t.Errorf("Validate accepted keys %q", keys)
+		}
+	}
+	if err := (&Config{NilMapProb: 1, MissingKeyProb: 0}).Validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"slice_practice/fixture"
+	"slice_practice/sliceaccess"
+)
+
+// 並列版が GOMAXPROCS や範囲の分け方によらず逐次版と同じsliceを作ることを確認する
+func TestCreateLargeSliceParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	for _, procs := range []int{1, 3, 8} {
+		runtime.GOMAXPROCS(procs)
+		for _, size := range []int{0, 1, 7, 10000} {
+			got, err := createLargeSliceParallel(context.Background(), size)
+			if err != nil {
+				t.Fatalf("procs=%d size=%d: %v", procs, size, err)
+			}
+			if want := createLargeSlice(size); !reflect.DeepEqual(got, want) {
+				t.Errorf("procs=%d size=%d: differs from createLargeSlice", procs, size)
+			}
+		}
+	}
+}
+
+func TestCreateLargeStructSliceParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	got, err := createLargeStructSliceParallel(context.Background(), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := createLargeStructSlice(100)
+	// 作成時刻は呼び出しごとに異なるので揃えてから比較する
+	created := got[0].Metadata["created"]
+	for i := range got {
+		if got[i].Metadata["created"] != created {
+			t.Fatalf("element %d has a different created time", i)
+		}
+		got[i].Metadata["created"] = want[i].Metadata["created"]
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Error("differs from createLargeStructSlice")
+	}
+}
+
+func TestBuildParallelCancel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if slice, err := createLargeSliceParallel(ctx, 1000); !errors.Is(err, context.Canceled) || slice != nil {
+		t.Errorf("canceled before start: got len %d, %v", len(slice), err)
+	}
+
+	// 作成の途中でキャンセルすると、残りの範囲は埋められずに止まる
+	const size = 1 << 20
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	var filled atomic.Int64
+	err := buildParallel(ctx, size, func(i int) {
+		if filled.Add(1) == 1 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v; want context.Canceled", err)
+	}
+	if n := filled.Load(); n >= size {
+		t.Errorf("filled %d of %d elements after cancel", n, size)
+	}
+}
+
+func BenchmarkCreateLargeSlice(b *testing.B) {
+	const size = 1000000
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			elementSink = createLargeSlice(size)
+		}
+	})
+	b.Run(fmt.Sprintf("parallel/procs=%d", runtime.GOMAXPROCS(0)), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			elementSink, _ = createLargeSliceParallel(context.Background(), size)
+		}
+	})
+}
+
+func BenchmarkCreateLargeStructSlice(b *testing.B) {
+	const size = 10000
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			elementSink = createLargeStructSlice(size)
+		}
+	})
+	b.Run(fmt.Sprintf("parallel/procs=%d", runtime.GOMAXPROCS(0)), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			elementSink, _ = createLargeStructSliceParallel(context.Background(), size)
+		}
+	})
+}
+
+// -data-seed のデータは並列に作っても fixture.Config.Maps と同じで、アクセス関数のエラー処理を通る
+func TestOptionsLargeSliceRandom(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	cfg := &fixture.Config{Seed: 9, NilMapProb: 0.2, MissingKeyProb: 0.3}
+	opts := options{fixture: cfg, ctx: context.Background()}
+	got, err := opts.largeSlice(10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, cfg.Maps(10000)) {
+		t.Error("largeSlice differs from fixture.Config.Maps")
+	}
+
+	var nilMaps, missingKeys int
+	for i := range got {
+		_, err := safeAccessMiddleMap(got[i:i+1], "key1")
+		var keyErr *sliceaccess.KeyNotFoundError
+		switch {
+		case errors.Is(err, sliceaccess.ErrNilMap):
+			nilMaps++
+		case errors.As(err, &keyErr):
+			missingKeys++
+		}
+	}
+	if nilMaps == 0 || missingKeys == 0 {
+		t.Errorf("nil maps = %d, missing keys = %d; want both error paths", nilMaps, missingKeys)
+	}
+
+	structs, err := opts.largeStructSlice(50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := opts.largeStructSlice(50)
+	for i := range structs {
+		if structs[i].Data != again[i].Data || !reflect.DeepEqual(structs[i].Values, again[i].Values) {
+			t.Fatalf("element %d differs between runs with the same seed", i)
+		}
+		if structs[i].Data[1] == i {
+			t.Fatalf("element %d still uses i*j data", i)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Package growsim は runtime.growslice を純粋なGoで再現したモデルで、append の後の容量を
+// 要素サイズとGoのバージョンごとに予測します。
+//
+// make([]LargeStruct, 0, n) のような事前確保を調整するときに、割り当ての多い実験を
+// 実行しなくても、どの長さで再割り当てが起きるかを確認できます。
+//
+// モデルは次の2段階で容量を決めます。
+//
+//  1. nextslicecap: 小さなsliceは2倍、大きなsliceは徐々に1.25倍へ近づく伸び率で新しい容量を決める
+//  2. roundupsize: 容量 × 要素サイズをメモリアロケータのサイズクラス（32KB超はページ単位）に切り上げ、
+//     余った分も容量に含める
+//
+// Go 1.25 以降のコンパイラは、エスケープしない小さなsliceの最初の内部配列をスタックに置くことがあります。
+// その場合は最初の数回の append が growslice を通らないため、このモデルの対象外です。
+package growsim
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Elem は要素の型の性質です
+type Elem struct {
+	Size        int  // unsafe.Sizeof の値
+	HasPointers bool // ポインタを含む型か（GCが走査する割り当てかどうかで切り上げ方が変わる）
+}
+
+// ElemOf は T の Elem を返します
+func ElemOf[T any](hasPointers bool) Elem {
+	var zero T
+	return Elem{Size: int(unsafe.Sizeof(zero)), HasPointers: hasPointers}
+}
+
+// Type は Elem と同じサイズとポインタの有無を持つ型を作成します。
+// ポインタを含まない場合は [Size]byte、含む場合は先頭にポインタを持つ構造体です。
+// ポインタを含む型のサイズはポインタのサイズの倍数でなければなりません。
+func (e Elem) Type() (reflect.Type, error) {
+	if !e.HasPointers {
+		return reflect.ArrayOf(e.Size, reflect.TypeFor[byte]()), nil
+	}
+	if e.Size < ptrSize || e.Size%ptrSize != 0 {
+		return nil, fmt.Errorf("element with pointers must be a multiple of %d bytes, got %d", ptrSize, e.Size)
+	}
+	if e.Size == ptrSize {
+		// 大きさ0の Pad を末尾に置くと構造体が大きくなるため、ポインタだけの型にする
+		return reflect.TypeFor[*byte](), nil
+	}
+	return reflect.StructOf([]reflect.StructField{
+		{Name: "P", Type: reflect.TypeFor[*byte]()},
+		{Name: "Pad", Type: reflect.ArrayOf(e.Size-ptrSize, reflect.TypeFor[byte]())},
+	}), nil
+}
+
+// Policy はGoのバージョンごとの growslice の振る舞いです
+type Policy struct {
+	Name string // 対象のGoのバージョン（go1.22+ など）
+
+	// threshold は2倍で伸びるのをやめる境界です
+	threshold int
+	// smooth は threshold 以降の伸び率を、Go 1.18 以降の滑らかな式で決めるかどうかです。
+	// false の場合は Go 1.17 以前と同じく、長さが threshold 以上なら1.25倍ずつ伸ばします。
+	smooth bool
+	// mallocHeader は、ポインタを含む512 bytes超の小さなオブジェクトに
+	// 8 bytesのヘッダを付けるアロケータ（Go 1.22 以降）かどうかです。
+	mallocHeader bool
+}
+
+var (
+	// Go117 は Go 1.17 以前の growslice です（1024要素未満は2倍、以降は1.25倍）
+	Go117 = Policy{Name: "go1.17", threshold: 1024}
+	// Go118 は Go 1.18〜1.21 の growslice です（256要素から滑らかに1.25倍へ近づく）
+	Go118 = Policy{Name: "go1.18-go1.21", threshold: 256, smooth: true}
+	// Go122 は Go 1.22 以降の growslice です（アロケータのヘッダを考慮する）
+	Go122 = Policy{Name: "go1.22+", threshold: 256, smooth: true, mallocHeader: true}
+)
+
+// Policies は古い順のすべての Policy です
+var Policies = []Policy{Go117, Go118, Go122}
+
+// PolicyFor は runtime.Version() 形式のGoのバージョン（go1.24.5 など）に対応する Policy を返します。
+// 解釈できないバージョン（devel など）には最新の Policy を返します。
+func PolicyFor(goVersion string) Policy {
+	minor, ok := goMinor(goVersion)
+	switch {
+	case !ok:
+		return Go122
+	case minor < 18:
+		return Go117
+	case minor < 22:
+		return Go118
+	}
+	return Go122
+}
+
+// goMinor は "go1.24.5" や "go1.25rc1" のマイナーバージョン（24、25）を返します
+func goMinor(v string) (int, bool) {
+	rest, ok := strings.CutPrefix(v, "go1.")
+	if !ok {
+		return 0, false
+	}
+	end := 0
+	for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+		end++
+	}
+	minor, err := strconv.Atoi(rest[:end])
+	return minor, err == nil
+}
+
+// String は Policy の名前を返します
+func (p Policy) String() string {
+	return p.Name
+}
+
+// nextCap は長さ oldLen、容量 oldCap のsliceを長さ newLen にするときの、
+// サイズクラスに切り上げる前の容量です
+func (p Policy) nextCap(oldLen, oldCap, newLen int) int {
+	newcap := oldCap
+	doublecap := newcap + newcap
+	if newLen > doublecap {
+		return newLen
+	}
+	if !p.smooth {
+		if oldLen < p.threshold {
+			return doublecap
+		}
+		for 0 < newcap && newcap < newLen {
+			newcap += newcap / 4
+		}
+		if newcap <= 0 {
+			return newLen
+		}
+		return newcap
+	}
+	if oldCap < p.threshold {
+		return doublecap
+	}
+	for {
+		newcap += (newcap + 3*p.threshold) >> 2
+		if uint(newcap) >= uint(newLen) {
+			break
+		}
+	}
+	if newcap <= 0 {
+		return newLen
+	}
+	return newcap
+}
+
+// GrowCap は要素 elem の長さ oldLen、容量 oldCap のsliceに append して長さを newLen にしたときの
+// 新しい容量を返します。newLen が oldCap 以下なら再割り当ては起きないので oldCap を返します。
+func (p Policy) GrowCap(elem Elem, oldLen, oldCap, newLen int) int {
+	if newLen <= oldCap {
+		return oldCap
+	}
+	if elem.Size == 0 {
+		return newLen
+	}
+	newcap := p.nextCap(oldLen, oldCap, newLen)
+	mem := p.roundupsize(newcap*elem.Size, !elem.HasPointers)
+	return mem / elem.Size
+}
+
+// Step は予測した1回の再割り当てです
+type Step struct {
+	Len        int // 再割り当てを起こした append の後の長さ
+	OldCap     int
+	NewCap     int
+	AllocBytes int // 新しい内部配列のバイト数（新しい容量 × 要素サイズ）
+}
+
+// String は1行の説明として Step を整形します
+func (s Step) String() string {
+	return fmt.Sprintf("len=%d cap %d→%d (%dB)", s.Len, s.OldCap, s.NewCap, s.AllocBytes)
+}
+
+// Simulate は make([]T, 0, initialCap) に1要素ずつ n 回 append したときの再割り当てを予測します
+func (p Policy) Simulate(elem Elem, initialCap, n int) []Step {
+	var steps []Step
+	c := initialCap
+	for l := 1; l <= n; l++ {
+		if l <= c {
+			continue
+		}
+		newCap := p.GrowCap(elem, l-1, c, l)
+		steps = append(steps, Step{Len: l, OldCap: c, NewCap: newCap, AllocBytes: newCap * elem.Size})
+		c = newCap
+	}
+	return steps
+}
+
+// ptrSize はポインタのバイト数です（モデルは実行中のアーキテクチャのものを使います）
+const ptrSize = int(unsafe.Sizeof(uintptr(0)))
+
+const (
+	maxSmallSize           = 32768
+	pageSize               = 8192
+	mallocHeaderSize       = 8
+	minSizeForMallocHeader = ptrSize * ptrSize * 8
+)
+
+// sizeClasses はメモリアロケータの小さなオブジェクトのサイズクラスです
+// （runtime の class_to_size から0を除いたもの）
+var sizeClasses = []int{
+	8, 16, 24, 32, 48, 64, 80, 96, 112, 128, 144, 160, 176, 192, 208, 224, 240, 256,
+	288, 320, 352, 384, 416, 448, 480, 512, 576, 640, 704, 768, 896, 1024, 1152, 1280,
+	1408, 1536, 1792, 2048, 2304, 2688, 3072, 3200, 3456, 4096, 4864, 5376, 6144, 6528,
+	6784, 6912, 8192, 9472, 9728, 10240, 10880, 12288, 13568, 14336, 16384, 18432, 19072,
+	20480, 21760, 24576, 27264, 28672, 32768,
+}
+
+// sizeClass は size 以上で最小のサイズクラスを返します
+func sizeClass(size int) int {
+	for _, c := range sizeClasses {
+		if c >= size {
+			return c
+		}
+	}
+	return maxSmallSize
+}
+
+// roundupsize は size バイトの割り当てで実際に確保されるバイト数を返します。
+// アロケータのヘッダを付ける場合は、その分を差し引いた使えるバイト数を返します。
+func (p Policy) roundupsize(size int, noscan bool) int {
+	if !p.mallocHeader {
+		if size < maxSmallSize {
+			return sizeClass(size)
+		}
+		return alignPage(size)
+	}
+	if size <= maxSmallSize-mallocHeaderSize {
+		req := size
+		if !noscan && req > minSizeForMallocHeader {
+			req += mallocHeaderSize
+		}
+		return sizeClass(req) - (req - size)
+	}
+	return alignPage(size)
+}
+
+// alignPage は size をページサイズの倍数に切り上げます
+func alignPage(size int) int {
+	r := size + pageSize - 1
+	if r < size {
+		return size
+	}
+	return r &^ (pageSize - 1)
+}
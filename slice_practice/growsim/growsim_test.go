@@ -0,0 +1,139 @@
+package growsim
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+// elemType は e.Type() の型を返し、サイズが e.Size と一致することを確認します
+func elemType(t *testing.T, e Elem) reflect.Type {
+	t.Helper()
+	typ, err := e.Type()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(typ.Size()) != e.Size {
+		t.Fatalf("element type %v has size %d, want %d", typ, typ.Size(), e.Size)
+	}
+	return typ
+}
+
+// observe は実際のランタイムで make(0, initialCap) に1要素ずつ n 回 append したときの再割り当てを記録します。
+// reflect.Append は append と同じ runtime.growslice を使います。
+func observe(t *testing.T, e Elem, initialCap, n int) []Step {
+	typ := elemType(t, e)
+	s := reflect.MakeSlice(reflect.SliceOf(typ), 0, initialCap)
+	zero := reflect.Zero(typ)
+	var steps []Step
+	for i := 0; i < n; i++ {
+		oldCap := s.Cap()
+		s = reflect.Append(s, zero)
+		if s.Cap() != oldCap {
+			steps = append(steps, Step{Len: s.Len(), OldCap: oldCap, NewCap: s.Cap(), AllocBytes: s.Cap() * e.Size})
+		}
+	}
+	return steps
+}
+
+// testElems はモデルと実際のランタイムを比べる要素です。
+// 2のべき乗、ポインタサイズ、半端なサイズ、サイズクラスの境界付近、LargeStruct 相当の大きさを含みます。
+var testElems = func() []Elem {
+	var elems []Elem
+	for _, size := range []int{1, 2, 3, 4, 5, 7, 8, 12, 16, 24, 40, 48, 56, 64, 72, 100, 128, 200, 256, 384, 520, 1000, 1024, 4000, 8056, 33000} {
+		elems = append(elems, Elem{Size: size})
+		if size >= ptrSize && size%ptrSize == 0 {
+			elems = append(elems, Elem{Size: size, HasPointers: true})
+		}
+	}
+	return elems
+}()
+
+func TestSimulateMatchesRuntime(t *testing.T) {
+	p := PolicyFor(runtime.Version())
+	for _, e := range testElems {
+		// 要素サイズによらず数MBまでの伸び方を確認する
+		n := min(20000, 4<<20/e.Size)
+		for _, initialCap := range []int{0, 3, 100} {
+			got := p.Simulate(e, initialCap, n)
+			want := observe(t, e, initialCap, n)
+			if len(got) != len(want) {
+				t.Errorf("%+v cap %d: simulated %d reallocations, runtime did %d\nsim: %v\nrt:  %v", e, initialCap, len(got), len(want), got, want)
+				continue
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Errorf("%+v cap %d: step %d = %v, runtime %v", e, initialCap, i, got[i], want[i])
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestGrowCapMatchesRuntimeForMultiAppend(t *testing.T) {
+	p := PolicyFor(runtime.Version())
+	for _, e := range testElems {
+		typ := elemType(t, e)
+		for _, c := range []struct{ len, add int }{{0, 5}, {4, 1}, {10, 25}, {300, 1}, {300, 700}, {1000, 1}} {
+			s := reflect.MakeSlice(reflect.SliceOf(typ), c.len, c.len)
+			s = reflect.AppendSlice(s, reflect.MakeSlice(s.Type(), c.add, c.add))
+			if got := p.GrowCap(e, c.len, c.len, c.len+c.add); got != s.Cap() {
+				t.Errorf("%+v len %d + %d: GrowCap = %d, runtime cap %d", e, c.len, c.add, got, s.Cap())
+			}
+		}
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	elem := Elem{Size: 8}
+	tests := []struct {
+		p                      Policy
+		oldLen, oldCap, newLen int
+		want                   int
+	}{
+		// 小さなsliceは2倍
+		{Go117, 4, 4, 5, 8},
+		{Go122, 4, 4, 5, 8},
+		// Go 1.17 は1024要素まで2倍
+		{Go117, 512, 512, 513, 1024},
+		// Go 1.18 以降は256要素から伸び率が下がる（832要素 → サイズクラス 6784 bytes）
+		{Go118, 512, 512, 513, 848},
+		// Go 1.17 は1024要素以降1.25倍（1280要素 → 10240 bytes）
+		{Go117, 1024, 1024, 1025, 1280},
+		// 2倍でも足りなければ必要な長さから切り上げる
+		{Go122, 2, 2, 11, 12},
+		// 容量内なら再割り当てしない
+		{Go122, 2, 8, 5, 8},
+	}
+	for _, tt := range tests {
+		if got := tt.p.GrowCap(elem, tt.oldLen, tt.oldCap, tt.newLen); got != tt.want {
+			t.Errorf("%v.GrowCap(len %d, cap %d → %d) = %d, want %d", tt.p, tt.oldLen, tt.oldCap, tt.newLen, got, tt.want)
+		}
+	}
+
+	// アロケータのヘッダは、ポインタを含む512 bytes超の割り当てだけに影響する
+	ptrs := Elem{Size: 8, HasPointers: true}
+	if got := Go118.GrowCap(ptrs, 64, 64, 65); got != 128 {
+		t.Errorf("go1.18 pointer elements: cap %d, want 128", got)
+	}
+	if got := Go122.GrowCap(ptrs, 64, 64, 65); got != 143 {
+		t.Errorf("go1.22 pointer elements: cap %d, want 143 (1152-byte class minus header)", got)
+	}
+}
+
+func TestPolicyFor(t *testing.T) {
+	tests := map[string]Policy{
+		"go1.17.13":  Go117,
+		"go1.18":     Go118,
+		"go1.21.0":   Go118,
+		"go1.22.0":   Go122,
+		"go1.25rc1":  Go122,
+		"devel +abc": Go122,
+	}
+	for v, want := range tests {
+		if got := PolicyFor(v); got.Name != want.Name {
+			t.Errorf("PolicyFor(%q) = %v, want %v", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"reflect"
+	"runtime"
+
+	"slice_practice/growsim"
+	"slice_practice/sliceaccess"
+)
+
+// runGrowth は growth サブコマンドを実行します
+//
+//	slice_practice growth [-elemsize=bytes] [-n=count] [-cap=n] [-pointers] [-go=version]
+//
+// -cap か -go を指定した場合は、実際に append する代わりに growsim のモデルで再割り当てを予測します。
+// -elemsize を省略すると LargeStruct を要素にします。
+func runGrowth(args []string) int {
+	fs := flag.NewFlagSet("growth", flag.ContinueOnError)
+	elemSize := fs.Int("elemsize", getCopySize(), msg.T("要素のサイズ（bytes）。省略時は LargeStruct のサイズ"))
+	n := fs.Int("n", 10000, msg.T("1要素ずつ append する回数"))
+	initialCap := fs.Int("cap", 0, msg.T("make で確保しておく容量（予測のみ）"))
+	pointers := fs.Bool("pointers", false, msg.T("要素がポインタを含む（-elemsize 指定時。省略時の LargeStruct は含む）"))
+	goVersion := fs.String("go", "", msg.T("予測に使うGoのバージョン（go1.17 など）"))
+	lang := fs.String("lang", "", msg.T("表示言語（ja, en）。省略時は LANG から判定"))
+	fs.Usage = func() {
+		msg.Fprintln(fs.Output(), "使い方: slice_practice growth [-elemsize=bytes] [-n=count] [-cap=n] [-pointers] [-go=version]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if !setLang(*lang) {
+		return 2
+	}
+	if fs.NArg() != 0 || *elemSize < 0 || *n < 0 || *initialCap < 0 {
+		fs.Usage()
+		return 2
+	}
+
+	// -elemsize を省略した場合は LargeStruct そのもの（ポインタを含む）の伸び方を表示する
+	elemSizeSet := false
+	fs.Visit(func(f *flag.Flag) { elemSizeSet = elemSizeSet || f.Name == "elemsize" })
+	elem := growsim.Elem{Size: *elemSize, HasPointers: *pointers || !elemSizeSet}
+
+	if *goVersion == "" && *initialCap == 0 {
+		elemType := reflect.TypeFor[LargeStruct]()
+		if elemSizeSet {
+			var err error
+			if elemType, err = elem.Type(); err != nil {
+				msg.Fprintf(os.Stderr, "エラー: %v\n", err)
+				return 2
+			}
+		}
+		msg.Fprintf(os.Stdout, "%s（%d bytes、ポインタ: %v）のsliceに1要素ずつ append したときの容量の伸び方:\n",
+			elemType, elemType.Size(), elem.HasPointers)
+		sliceaccess.WriteGrowthTable(os.Stdout, sliceaccess.GrowthCurve(elemType, *n))
+		return 0
+	}
+
+	if *goVersion == "" {
+		*goVersion = runtime.Version()
+	}
+	policy := growsim.PolicyFor(*goVersion)
+	msg.Fprintf(os.Stdout, "make(0, %d) の要素サイズ %d bytes（ポインタ: %v）のsliceに1要素ずつ append したときの予測（%s）:\n",
+		*initialCap, elem.Size, elem.HasPointers, policy)
+	var growths []sliceaccess.Growth
+	for _, s := range policy.Simulate(elem, *initialCap, *n) {
+		g := sliceaccess.Growth{
+			Len:         s.Len,
+			OldCap:      s.OldCap,
+			NewCap:      s.NewCap,
+			BytesCopied: s.OldCap * elem.Size,
+			AllocBytes:  s.AllocBytes,
+		}
+		if s.OldCap > 0 {
+			g.Factor = float64(s.NewCap) / float64(s.OldCap)
+		}
+		growths = append(growths, g)
+	}
+	sliceaccess.WriteGrowthTable(os.Stdout, growths)
+	return 0
+}
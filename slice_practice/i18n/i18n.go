@@ -0,0 +1,127 @@
+// Package i18n は、日本語のメッセージをキーにした簡単なメッセージカタログです。
+//
+// golang.org/x/text/message と同じく、ソースコード中の日本語の書式文字列を
+// そのままキーとして使います。翻訳が登録されていないメッセージは日本語のまま表示されます。
+package i18n
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Lang は表示言語です
+type Lang string
+
+const (
+	Ja Lang = "ja" // 日本語（ソースコードの言語で、翻訳がない場合のフォールバック）
+	En Lang = "en"
+)
+
+// ParseLang は -lang フラグや LANG 環境変数の値を解釈します。
+// "en_US.UTF-8" のような地域や文字コード付きの値も受け付けます。
+func ParseLang(s string) (Lang, bool) {
+	s = strings.ToLower(s)
+	if i := strings.IndexAny(s, "_.-@"); i >= 0 {
+		s = s[:i]
+	}
+	switch Lang(s) {
+	case Ja, En:
+		return Lang(s), true
+	}
+	return "", false
+}
+
+// Detect は flagValue が空でなければそれを、空であれば LC_ALL、LC_MESSAGES、LANG の
+// 順に環境変数を調べて表示言語を決めます。判断できない場合は Ja を返します。
+func Detect(flagValue string) Lang {
+	if flagValue != "" {
+		if lang, ok := ParseLang(flagValue); ok {
+			return lang
+		}
+		return Ja
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			if lang, ok := ParseLang(v); ok {
+				return lang
+			}
+			// 最初に設定されている変数が優先される
+			return Ja
+		}
+	}
+	return Ja
+}
+
+var (
+	mu       sync.RWMutex
+	catalogs = map[Lang]map[string]string{}
+)
+
+// SetCatalog は lang の翻訳（日本語のメッセージ → 翻訳）を登録します
+func SetCatalog(lang Lang, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	c := catalogs[lang]
+	if c == nil {
+		c = make(map[string]string, len(messages))
+		catalogs[lang] = c
+	}
+	for k, v := range messages {
+		c[k] = v
+	}
+}
+
+// Lookup は lang での key の翻訳を返します
+func Lookup(lang Lang, key string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	msg, ok := catalogs[lang][key]
+	return msg, ok
+}
+
+// Printer は1つの言語でメッセージを書き出します
+type Printer struct {
+	lang Lang
+}
+
+// NewPrinter は lang でメッセージを書き出す Printer を作成します
+func NewPrinter(lang Lang) *Printer {
+	return &Printer{lang: lang}
+}
+
+// Lang は Printer の表示言語を返します
+func (p *Printer) Lang() Lang {
+	return p.lang
+}
+
+// T はメッセージを翻訳します。前後の空白や改行はキーに含めず、そのまま残します。
+func (p *Printer) T(msg string) string {
+	if p.lang == Ja {
+		return msg
+	}
+	trimmed := strings.TrimSpace(msg)
+	translated, ok := Lookup(p.lang, trimmed)
+	if !ok || trimmed == "" {
+		return msg
+	}
+	start := strings.Index(msg, trimmed)
+	return msg[:start] + translated + msg[start+len(trimmed):]
+}
+
+// Sprintf は書式文字列を翻訳してから fmt.Sprintf と同じように整形します
+func (p *Printer) Sprintf(format string, a ...any) string {
+	return fmt.Sprintf(p.T(format), a...)
+}
+
+// Fprintf は書式文字列を翻訳してから fmt.Fprintf と同じように書き出します
+func (p *Printer) Fprintf(w io.Writer, format string, a ...any) (int, error) {
+	return fmt.Fprintf(w, p.T(format), a...)
+}
+
+// Fprintln はメッセージを翻訳し、改行を付けて書き出します
+func (p *Printer) Fprintln(w io.Writer, msg string) (int, error) {
+	return fmt.Fprintln(w, p.T(msg))
+}
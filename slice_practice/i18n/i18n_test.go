@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseLang(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Lang
+		ok   bool
+	}{
+		{"ja", Ja, true},
+		{"EN", En, true},
+		{"en_US.UTF-8", En, true},
+		{"ja_JP.UTF-8", Ja, true},
+		{"C", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseLang(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseLang(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+	if got := Detect(""); got != En {
+		t.Errorf("Detect with LANG=en_US.UTF-8 = %q; want en", got)
+	}
+	if got := Detect("ja"); got != Ja {
+		t.Errorf("Detect(ja) = %q; want ja", got)
+	}
+	t.Setenv("LC_ALL", "C")
+	if got := Detect(""); got != Ja {
+		t.Errorf("Detect with LC_ALL=C = %q; want ja", got)
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	SetCatalog(En, map[string]string{
+		"値: %d": "value: %d",
+	})
+	en := NewPrinter(En)
+	if got := en.Sprintf("  値: %d\n", 3); got != "  value: 3\n" {
+		t.Errorf("Sprintf = %q; want surrounding whitespace kept", got)
+	}
+	// 翻訳がないメッセージは日本語のまま
+	if got := en.T("未翻訳"); got != "未翻訳" {
+		t.Errorf("T(未翻訳) = %q", got)
+	}
+	if got := NewPrinter(Ja).Sprintf("値: %d", 3); got != "値: 3" {
+		t.Errorf("ja Sprintf = %q", got)
+	}
+
+	var buf bytes.Buffer
+	en.Fprintln(&buf, "値: %d")
+	if buf.String() != "value: %d\n" {
+		t.Errorf("Fprintln = %q", buf.String())
+	}
+}
@@ -0,0 +1,158 @@
+// Package layout は、構造体の各フィールドのオフセット、サイズ、アラインメント、
+// 後ろに入るパディングを調べ、パディングが最小になるフィールドの並び順を提案します。
+package layout
+
+import (
+	"fmt"
+	"go/types"
+	"io"
+	"reflect"
+	"runtime"
+	"slices"
+	"text/tabwriter"
+)
+
+// Field は1フィールドの配置です
+type Field struct {
+	Name    string
+	Type    string
+	Offset  int64
+	Size    int64
+	Align   int64
+	Padding int64 // このフィールドの直後に入るパディング
+}
+
+// Layout は構造体全体の配置です
+type Layout struct {
+	Type   string
+	Arch   string
+	Size   int64
+	Align  int64
+	Fields []Field
+}
+
+// Padding はパディングの合計を返します
+func (l Layout) Padding() int64 {
+	var total int64
+	for _, f := range l.Fields {
+		total += f.Padding
+	}
+	return total
+}
+
+// Of は実行中のプログラム（ホストのアーキテクチャ）での構造体の配置を返します。
+// t がポインタ型の場合は指している先の構造体を対象にします。
+func Of(t reflect.Type) (Layout, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return Layout{}, fmt.Errorf("%s is not a struct type", t)
+	}
+
+	l := Layout{
+		Type:  t.String(),
+		Arch:  runtime.GOARCH,
+		Size:  int64(t.Size()),
+		Align: int64(t.Align()),
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		l.Fields = append(l.Fields, Field{
+			Name:   f.Name,
+			Type:   f.Type.String(),
+			Offset: int64(f.Offset),
+			Size:   int64(f.Type.Size()),
+			Align:  int64(f.Type.FieldAlign()),
+		})
+	}
+	l.fillPadding()
+	return l, nil
+}
+
+// OfTypes は go/types の構造体について、sizes（types.SizesFor で取得）に
+// 従った配置を返します。ホスト以外の GOARCH での配置を調べるときに使います。
+func OfTypes(name string, st *types.Struct, sizes types.Sizes, arch string) Layout {
+	fields := make([]*types.Var, st.NumFields())
+	for i := range fields {
+		fields[i] = st.Field(i)
+	}
+	offsets := sizes.Offsetsof(fields)
+
+	l := Layout{
+		Type:  name,
+		Arch:  arch,
+		Size:  sizes.Sizeof(st),
+		Align: sizes.Alignof(st),
+	}
+	for i, f := range fields {
+		l.Fields = append(l.Fields, Field{
+			Name:   f.Name(),
+			Type:   types.TypeString(f.Type(), types.RelativeTo(f.Pkg())),
+			Offset: offsets[i],
+			Size:   sizes.Sizeof(f.Type()),
+			Align:  sizes.Alignof(f.Type()),
+		})
+	}
+	l.fillPadding()
+	return l
+}
+
+// fillPadding は各フィールドの後ろのパディングを計算します
+func (l *Layout) fillPadding() {
+	for i := range l.Fields {
+		next := l.Size
+		if i+1 < len(l.Fields) {
+			next = l.Fields[i+1].Offset
+		}
+		l.Fields[i].Padding = next - (l.Fields[i].Offset + l.Fields[i].Size)
+	}
+}
+
+// Optimize はパディングが最小になるようにフィールドを並べ替えた配置を返します。
+// アラインメントの大きい順（同じならサイズの大きい順）に並べます。
+// サイズ0のフィールドは、末尾に置くとパディングが入るため先頭に移します。
+func Optimize(l Layout) Layout {
+	fields := slices.Clone(l.Fields)
+	slices.SortStableFunc(fields, func(a, b Field) int {
+		if (a.Size == 0) != (b.Size == 0) {
+			if a.Size == 0 {
+				return -1
+			}
+			return 1
+		}
+		if a.Align != b.Align {
+			return int(b.Align - a.Align)
+		}
+		return int(b.Size - a.Size)
+	})
+
+	opt := Layout{Type: l.Type, Arch: l.Arch, Align: l.Align, Fields: fields}
+	var offset int64
+	for i := range opt.Fields {
+		offset = alignUp(offset, opt.Fields[i].Align)
+		opt.Fields[i].Offset = offset
+		offset += opt.Fields[i].Size
+	}
+	opt.Size = alignUp(offset, opt.Align)
+	opt.fillPadding()
+	return opt
+}
+
+func alignUp(n, align int64) int64 {
+	if align <= 1 {
+		return n
+	}
+	return (n + align - 1) / align * align
+}
+
+// Write はフィールドごとの配置を表形式で書き出します
+func (l Layout) Write(w io.Writer) error {
+	fmt.Fprintf(w, "%s (%s): size=%d align=%d padding=%d\n", l.Type, l.Arch, l.Size, l.Align, l.Padding())
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  offset\tsize\talign\tpadding\tfield")
+	for _, f := range l.Fields {
+		fmt.Fprintf(tw, "  %d\t%d\t%d\t%d\t%s %s\n", f.Offset, f.Size, f.Align, f.Padding, f.Name, f.Type)
+	}
+	return tw.Flush()
+}
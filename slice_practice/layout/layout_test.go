@@ -0,0 +1,52 @@
+package layout
+
+import (
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+type padded struct {
+	A bool
+	B int64
+	C bool
+	D int32
+}
+
+func TestOfAndOptimize(t *testing.T) {
+	l, err := Of(reflect.TypeFor[padded]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Size != 24 || l.Padding() != 10 {
+		t.Fatalf("Of: size=%d padding=%d, want size=24 padding=10", l.Size, l.Padding())
+	}
+	if got := l.Fields[0].Padding; got != 7 {
+		t.Errorf("padding after A = %d, want 7", got)
+	}
+
+	opt := Optimize(l)
+	if opt.Size != 16 || opt.Padding() != 2 {
+		t.Errorf("Optimize: size=%d padding=%d, want size=16 padding=2", opt.Size, opt.Padding())
+	}
+	if opt.Fields[0].Name != "B" {
+		t.Errorf("Optimize: first field = %s, want B", opt.Fields[0].Name)
+	}
+}
+
+func TestOfTypesArch(t *testing.T) {
+	st := types.NewStruct([]*types.Var{
+		types.NewField(0, nil, "A", types.Typ[types.Bool], false),
+		types.NewField(0, nil, "B", types.Typ[types.Int64], false),
+	}, nil)
+
+	// 386 では int64 のアラインメントは4
+	l := OfTypes("padded", st, types.SizesFor("gc", "386"), "386")
+	if l.Size != 12 || l.Fields[1].Offset != 4 {
+		t.Errorf("386: size=%d offset(B)=%d, want 12 and 4", l.Size, l.Fields[1].Offset)
+	}
+	l = OfTypes("padded", st, types.SizesFor("gc", "amd64"), "amd64")
+	if l.Size != 16 || l.Fields[1].Offset != 8 {
+		t.Errorf("amd64: size=%d offset(B)=%d, want 16 and 8", l.Size, l.Fields[1].Offset)
+	}
+}
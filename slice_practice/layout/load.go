@@ -0,0 +1,56 @@
+package layout
+
+import (
+	"fmt"
+	"go/types"
+	"os"
+	"runtime"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Load は "<pkg>.<Type>" 形式の名前の構造体を読み込み、goarch での配置を返します。
+// goarch が空の場合はホストの GOARCH を使います。
+func Load(name, goarch string) (Layout, error) {
+	dot := strings.LastIndex(name, ".")
+	if dot <= 0 || dot == len(name)-1 {
+		return Layout{}, fmt.Errorf("invalid type name %q: want <pkg>.<Type>", name)
+	}
+	pkgPath, typeName := name[:dot], name[dot+1:]
+	if goarch == "" {
+		goarch = runtime.GOARCH
+	}
+
+	sizes := types.SizesFor("gc", goarch)
+	if sizes == nil {
+		return Layout{}, fmt.Errorf("unknown GOARCH %q", goarch)
+	}
+
+	// 依存パッケージもソースから型チェックし、ツールチェーンのexport data形式に依存しない
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedImports | packages.NeedDeps,
+		Env:  append(os.Environ(), "GOARCH="+goarch),
+	}
+	pkgs, err := packages.Load(cfg, pkgPath)
+	if err != nil {
+		return Layout{}, err
+	}
+	if len(pkgs) != 1 {
+		return Layout{}, fmt.Errorf("package %q matched %d packages", pkgPath, len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return Layout{}, pkg.Errors[0]
+	}
+
+	obj := pkg.Types.Scope().Lookup(typeName)
+	if obj == nil {
+		return Layout{}, fmt.Errorf("type %s not found in package %s", typeName, pkgPath)
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return Layout{}, fmt.Errorf("%s is not a struct type", name)
+	}
+	return OfTypes(pkg.Name+"."+typeName, st, sizes, goarch), nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+
+	"slice_practice/layout"
+)
+
+// runLayout は layout サブコマンドを実行します
+//
+//	slice_practice layout [-goarch=arch] <pkg>.<Type>
+func runLayout(args []string) int {
+	fs := flag.NewFlagSet("layout", flag.ContinueOnError)
+	goarch := fs.String("goarch", "", msg.T("対象のGOARCH（省略時はホスト）"))
+	lang := fs.String("lang", "", msg.T("表示言語（ja, en）。省略時は LANG から判定"))
+	fs.Usage = func() {
+		msg.Fprintln(fs.Output(), "使い方: slice_practice layout [-goarch=arch] <pkg>.<Type>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if !setLang(*lang) {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	l, err := layout.Load(fs.Arg(0), *goarch)
+	if err != nil {
+		msg.Fprintf(os.Stderr, "エラー: %v\n", err)
+		return 1
+	}
+
+	msg.Fprintln(os.Stdout, "現在のフィールド順:")
+	l.Write(os.Stdout)
+
+	opt := layout.Optimize(l)
+	if opt.Size < l.Size {
+		msg.Fprintf(os.Stdout, "\nパディングが最小になるフィールド順（%d bytes 削減）:\n", l.Size-opt.Size)
+		opt.Write(os.Stdout)
+	} else {
+		msg.Fprintln(os.Stdout, "\n✅ 現在のフィールド順でパディングは最小です")
+	}
+	return 0
+}
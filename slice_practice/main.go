@@ -3,8 +3,15 @@ package main
 import (
 	"errors"
 	"fmt"
+	"os"
+	"reflect"
+	"strings"
 	"time"
 	"unsafe"
+
+	"slice_practice/measure"
+	"slice_practice/memsize"
+	"slice_practice/sliceaccess"
 )
 
 // 大きな構造体を定義
@@ -16,65 +23,84 @@ type LargeStruct struct {
 	Values   []float64
 }
 
+// resultSink は計測ループの結果を受け取り、コンパイラにループを消されないようにします。
+// 正確な計測には go test -bench を使ってください（bench_test.go）。
+var resultSink int
+
 func main() {
-	fmt.Println("=== 大きなsliceの真ん中のmap要素への安全で効率的なアクセス方法 ===")
+	os.Exit(run(os.Args[1:]))
+}
+
+// runAccessDemo は大きなsliceの真ん中のmap要素に3つの方法でアクセスします
+func runAccessDemo(opts options) {
+	msg.Fprintln(out, "=== 大きなsliceの真ん中のmap要素への安全で効率的なアクセス方法 ===")
 
 	// 大きなsliceを作成（mapを要素として持つ）
-	largeSlice := createLargeSlice(1000000)
-	fmt.Printf("作成したsliceのサイズ: %d\n", len(largeSlice))
+	var largeSlice []map[string]int
+	var err error
+	d, mem := measure.Section(func() { largeSlice, err = opts.largeSlice(opts.sizeOr(1000000)) })
+	if err != nil {
+		msg.Fprintf(out, "エラー: %v\n", err)
+		return
+	}
+	msg.Fprintf(out, "作成したsliceのサイズ: %d\n", len(largeSlice))
+	printSection("作成時間", d, mem)
+	recordSection("access", "createLargeSlice", "map[string]int", len(largeSlice), d, mem)
 
 	// 方法1: 基本的な安全なアクセス
-	fmt.Println("\n--- 方法1: 基本的な安全なアクセス ---")
-	value, err := safeAccessMiddleMap(largeSlice, "key1")
+	msg.Fprintln(out, "\n--- 方法1: 基本的な安全なアクセス ---")
+	value, err := safeAccessMiddleMap(largeSlice, opts.key)
 	if err != nil {
-		fmt.Printf("エラー: %v\n", err)
+		msg.Fprintf(out, "エラー: %v\n", err)
 	} else {
-		fmt.Printf("真ん中のmapのkey1の値: %v\n", value)
+		msg.Fprintf(out, "真ん中のmapの%sの値: %v\n", opts.key, value)
 	}
 
 	// 方法2: より効率的なアクセス（境界チェックを最小限に）
-	fmt.Println("\n--- 方法2: 効率的なアクセス ---")
-	value2, err2 := efficientAccessMiddleMap(largeSlice, "key2")
+	msg.Fprintln(out, "\n--- 方法2: 効率的なアクセス ---")
+	value2, err2 := efficientAccessMiddleMap(largeSlice, opts.key)
 	if err2 != nil {
-		fmt.Printf("エラー: %v\n", err2)
+		msg.Fprintf(out, "エラー: %v\n", err2)
 	} else {
-		fmt.Printf("真ん中のmapのkey2の値: %v\n", value2)
+		msg.Fprintf(out, "真ん中のmapの%sの値: %v\n", opts.key, value2)
 	}
 
 	// 方法3: ポインタを使用した効率的なアクセス
-	fmt.Println("\n--- 方法3: ポインタを使用した効率的なアクセス ---")
-	value3, err3 := pointerAccessMiddleMap(largeSlice, "key3")
+	msg.Fprintln(out, "\n--- 方法3: ポインタを使用した効率的なアクセス ---")
+	value3, err3 := pointerAccessMiddleMap(largeSlice, opts.key)
 	if err3 != nil {
-		fmt.Printf("エラー: %v\n", err3)
+		msg.Fprintf(out, "エラー: %v\n", err3)
 	} else {
-		fmt.Printf("真ん中のmapのkey3の値: %v\n", value3)
+		msg.Fprintf(out, "真ん中のmapの%sの値: %v\n", opts.key, value3)
 	}
 
 	// 空のsliceでのテスト
-	fmt.Println("\n--- 空のsliceでのテスト ---")
+	msg.Fprintln(out, "\n--- 空のsliceでのテスト ---")
 	emptySlice := make([]map[string]int, 0)
-	_, err4 := safeAccessMiddleMap(emptySlice, "key1")
-	if err4 != nil {
-		fmt.Printf("期待通りのエラー: %v\n", err4)
+	_, err4 := safeAccessMiddleMap(emptySlice, opts.key)
+	if errors.Is(err4, sliceaccess.ErrEmptySlice) {
+		msg.Fprintf(out, "期待通りのエラー: %v\n", err4)
 	}
 
-	// パフォーマンステストを実行
-	runPerformanceTest()
+	// 存在しないキーでのテスト
+	msg.Fprintln(out, "\n--- 存在しないキーでのテスト ---")
+	_, err5 := efficientAccessMiddleMap(largeSlice, "missing")
+	var keyErr *sliceaccess.KeyNotFoundError
+	if errors.As(err5, &keyErr) {
+		msg.Fprintf(out, "期待通りのエラー: キー %v がインデックス %d のmapに存在しません\n", keyErr.Key, keyErr.Index)
+	}
+}
 
+// runDangerDemo はポインタアクセスの危険性と、その解決策を順に示します
+func runDangerDemo(options) {
 	// ポインタアクセスの危険性をデモンストレーション
 	demonstratePointerDanger()
 
 	// より詳細な危険性の例
 	demonstrateDetailedDanger()
 
-	// メモリ管理の仕組みを詳しく説明
-	explainMemoryManagement()
-
-	// 実際の使用パターンを検証
-	testRealWorldUsage()
-
-	// 大きな要素と多数の要素でのパフォーマンス比較
-	testLargeElementsPerformance()
+	// ハンドルを使った安全な参照
+	demonstrateStableHandles()
 }
 
 // createLargeSlice は指定されたサイズの大きなsliceを作成します
@@ -83,100 +109,48 @@ func createLargeSlice(size int) []map[string]int {
 
 	// 各要素にmapを初期化
 	for i := 0; i < size; i++ {
-		slice[i] = make(map[string]int)
-		// テスト用のデータを設定
-		slice[i]["key1"] = i * 10
-		slice[i]["key2"] = i * 20
-		slice[i]["key3"] = i * 30
+		slice[i] = newLargeSliceElem(i)
 	}
 
 	return slice
 }
 
-// 方法1: 基本的な安全なアクセス
-// 最も安全だが、毎回境界チェックを行う
-func safeAccessMiddleMap(slice []map[string]int, key string) (int, error) {
-	// 空のsliceチェック
-	if len(slice) == 0 {
-		return 0, errors.New("slice is empty")
-	}
-
-	// 真ん中のインデックスを計算
-	middleIndex := len(slice) / 2
-
-	// 境界チェック（実際には不要だが、明示的にチェック）
-	if middleIndex >= len(slice) {
-		return 0, errors.New("index out of bounds")
-	}
-
-	// mapの存在チェック
-	middleMap := slice[middleIndex]
-	if middleMap == nil {
-		return 0, errors.New("map at middle index is nil")
-	}
-
-	// キーの存在チェック
-	value, exists := middleMap[key]
-	if !exists {
-		return 0, fmt.Errorf("key '%s' not found in map", key)
+// newLargeSliceElem は createLargeSlice の要素 i のmapを作成します
+func newLargeSliceElem(i int) map[string]int {
+	// テスト用のデータを設定
+	return map[string]int{
+		"key1": i * 10,
+		"key2": i * 20,
+		"key3": i * 30,
 	}
+}
 
-	return value, nil
+// 方法1: 基本的な安全なアクセス
+// 空のslice、nilのmap、存在しないキーをすべてエラーとして返す
+func safeAccessMiddleMap(slice []map[string]int, key string) (int, error) {
+	return sliceaccess.MiddleLookup(slice, key)
 }
 
 // 方法2: より効率的なアクセス
-// 境界チェックを最小限に抑え、パフォーマンスを重視
+// 真ん中のmapを値として取り出してから検索する（mapの値のコピーはポインタ1つ分）
 func efficientAccessMiddleMap(slice []map[string]int, key string) (int, error) {
-	// 空のsliceチェックのみ
-	if len(slice) == 0 {
-		return 0, errors.New("slice is empty")
-	}
-
-	// 真ん中のインデックスを計算（境界チェックは不要）
-	middleIndex := len(slice) / 2
-	middleMap := slice[middleIndex]
-
-	// mapの存在チェック
-	if middleMap == nil {
-		return 0, errors.New("map at middle index is nil")
-	}
-
-	// キーの存在チェック
-	value, exists := middleMap[key]
-	if !exists {
-		return 0, fmt.Errorf("key '%s' not found in map", key)
+	middleMap, err := sliceaccess.Middle(slice)
+	if err != nil {
+		return 0, err
 	}
-
-	return value, nil
+	return sliceaccess.Lookup(middleMap, key, sliceaccess.MiddleIndex(len(slice)))
 }
 
 // 方法3: ポインタを使用した効率的なアクセス
 // 最も効率的だが、ポインタの扱いに注意が必要
+// 注意: sliceが変更される可能性がある場合は危険
+// （-tags slicedebug では参照を取得した時点の内部配列を記録する）
 func pointerAccessMiddleMap(slice []map[string]int, key string) (int, error) {
-	// 空のsliceチェック
-	if len(slice) == 0 {
-		return 0, errors.New("slice is empty")
-	}
-
-	// 真ん中のインデックスを計算
-	middleIndex := len(slice) / 2
-
-	// ポインタを使用して直接アクセス（境界チェックなし）
-	// 注意: この方法は高速だが、sliceが変更される可能性がある場合は危険
-	middleMapPtr := &slice[middleIndex]
-
-	// mapの存在チェック
-	if *middleMapPtr == nil {
-		return 0, errors.New("map at middle index is nil")
-	}
-
-	// キーの存在チェック
-	value, exists := (*middleMapPtr)[key]
-	if !exists {
-		return 0, fmt.Errorf("key '%s' not found in map", key)
+	middleMapPtr, err := sliceaccess.MiddleRef(slice)
+	if err != nil {
+		return 0, err
 	}
-
-	return value, nil
+	return sliceaccess.Lookup(*middleMapPtr, key, sliceaccess.MiddleIndex(len(slice)))
 }
 
 // ベンチマーク用の関数群
@@ -196,301 +170,410 @@ func benchmarkPointerAccess(slice []map[string]int, key string) int {
 }
 
 // パフォーマンステスト用の関数
-func runPerformanceTest() {
-	fmt.Println("\n=== パフォーマンステスト ===")
+func runPerformanceTest(opts options) {
+	msg.Fprintln(out, "\n=== パフォーマンステスト ===")
 
 	// テスト用のsliceを作成
-	testSlice := createLargeSlice(1000000)
-	key := "key1"
+	testSlice, err := opts.largeSlice(opts.sizeOr(1000000))
+	if err != nil {
+		msg.Fprintf(out, "エラー: %v\n", err)
+		return
+	}
+	key := opts.key
+
+	// 各方法の実行時間を複数回測定し、外れ値を除いて比較
+	cfg := opts.measureConfig(1000)
+	msg.Fprintf(out, "各方法のパフォーマンス比較（%d回実行 × %d回計測）:\n", cfg.Iterations, cfg.Runs)
+
+	results := measure.RunCases(cfg, opts.seed, []measure.Case{
+		{Name: "safe", Fn: func() { resultSink += benchmarkSafeAccess(testSlice, key) }},
+		{Name: "efficient", Fn: func() { resultSink += benchmarkEfficientAccess(testSlice, key) }},
+		{Name: "pointer", Fn: func() { resultSink += benchmarkPointerAccess(testSlice, key) }},
+	})
+	result1, result2, result3 := results[0], results[1], results[2]
+	printResult("方法1 (安全なアクセス)", result1)
+	printResult("方法2 (効率的なアクセス)", result2)
+	printResult("方法3 (ポインタアクセス)", result3)
+	record("bench", "middle", "map[string]int", len(testSlice), result1, result2, result3)
+
+	msg.Fprintf(out, "\n効率性の比較（Mann-WhitneyのU検定, 有意水準 %.2f）:\n", cfg.Alpha)
+	printComparison("方法2", "方法1", result1, result2, cfg.Alpha)
+	printComparison("方法3", "方法1", result1, result3, cfg.Alpha)
+	printComparison("方法3", "方法2", result2, result3, cfg.Alpha)
+}
 
-	// 各方法の実行時間を測定（簡易版）
-	fmt.Println("各方法のパフォーマンス比較:")
+// printResult は計測結果の統計量を表示します
+func printResult(label string, r measure.Result) {
+	label = msg.T(label)
+	msg.Fprintf(out, "%s: 中央値 %.2f ns/op, 平均 %.2f ns/op (95%%信頼区間 %.2f〜%.2f), 標準偏差 %.2f (n=%d, 外れ値 %d)\n",
+		label, r.Median, r.Mean, r.CILow, r.CIHigh, r.StdDev, r.N, r.Outliers)
+	msg.Fprintf(out, "%s  メモリ: %.1f B/op, %.2f allocs/op, GC %d回 (停止 %v)\n",
+		indentOf(label), r.Mem.BytesPerOp(), r.Mem.AllocsPerOp(), r.Mem.GCCycles, r.Mem.GCPause)
+}
 
-	// 方法1のテスト
-	start := time.Now()
-	for i := 0; i < 1000; i++ {
-		benchmarkSafeAccess(testSlice, key)
-	}
-	duration1 := time.Since(start)
-	fmt.Printf("方法1 (安全なアクセス): %v (1000回実行)\n", duration1)
+// printSection は計測ループ以外の区間の実行時間とメモリ統計を表示します
+func printSection(label string, d time.Duration, m measure.MemStats) {
+	label = msg.T(label)
+	msg.Fprintf(out, "%s: %v, 割り当て %.1f MB (%d回), GC %d回 (停止 %v)\n",
+		label, d, float64(m.Bytes)/(1024*1024), m.Allocs, m.GCCycles, m.GCPause)
+}
 
-	// 方法2のテスト
-	start = time.Now()
-	for i := 0; i < 1000; i++ {
-		benchmarkEfficientAccess(testSlice, key)
-	}
-	duration2 := time.Since(start)
-	fmt.Printf("方法2 (効率的なアクセス): %v (1000回実行)\n", duration2)
+// indentOf はラベル先頭のインデントを返します
+func indentOf(label string) string {
+	return label[:len(label)-len(strings.TrimLeft(label, " "))]
+}
 
-	// 方法3のテスト
-	start = time.Now()
-	for i := 0; i < 1000; i++ {
-		benchmarkPointerAccess(testSlice, key)
+// printComparison は candidate が baseline より速いかどうかを、有意水準 alpha の検定結果とともに表示します。
+// alpha には計測に使った measure.Config の Alpha を渡します。
+func printComparison(candidateLabel, baselineLabel string, baseline, candidate measure.Result, alpha float64) {
+	c := measure.Compare(baseline, candidate, alpha)
+	candidateLabel, baselineLabel = msg.T(candidateLabel), msg.T(baselineLabel)
+	switch {
+	case !c.Significant:
+		msg.Fprintf(out, "%sと%sの差は有意ではない (%.2fx, p=%.4f)\n", candidateLabel, baselineLabel, c.Speedup, c.P)
+	case c.Speedup >= 1:
+		msg.Fprintf(out, "%sは%sより %.2fx 高速 (p=%.4f, 有意)\n", candidateLabel, baselineLabel, c.Speedup, c.P)
+	default:
+		msg.Fprintf(out, "%sは%sより %.2fx 低速 (p=%.4f, 有意)\n", candidateLabel, baselineLabel, 1/c.Speedup, c.P)
 	}
-	duration3 := time.Since(start)
-	fmt.Printf("方法3 (ポインタアクセス): %v (1000回実行)\n", duration3)
-
-	fmt.Printf("\n効率性の比較:\n")
-	fmt.Printf("方法2は方法1より %.2fx 高速\n", float64(duration1)/float64(duration2))
-	fmt.Printf("方法3は方法1より %.2fx 高速\n", float64(duration1)/float64(duration3))
-	fmt.Printf("方法3は方法2より %.2fx 高速\n", float64(duration2)/float64(duration3))
 }
 
 // ポインタアクセスの危険性をデモンストレーションする関数
 func demonstratePointerDanger() {
-	fmt.Println("\n=== ポインタアクセスの危険性デモンストレーション ===")
+	msg.Fprintln(out, "\n=== ポインタアクセスの危険性デモンストレーション ===")
 
 	// 小さなsliceでテスト（理解しやすくするため）
 	slice := createLargeSlice(5)
-	fmt.Printf("初期slice: %v\n", slice)
+	msg.Fprintf(out, "初期slice: %v\n", slice)
 
 	// 方法2（安全）: 真ん中の要素を取得
 	middleIndex := len(slice) / 2
-	fmt.Printf("真ん中のインデックス: %d\n", middleIndex)
+	msg.Fprintf(out, "真ん中のインデックス: %d\n", middleIndex)
 
 	// 方法2でアクセス
-	fmt.Println("\n--- 方法2（安全なアクセス）---")
+	msg.Fprintln(out, "\n--- 方法2（安全なアクセス）---")
 	value2, err2 := efficientAccessMiddleMap(slice, "key1")
 	if err2 == nil {
-		fmt.Printf("方法2で取得した値: %d\n", value2)
+		msg.Fprintf(out, "方法2で取得した値: %d\n", value2)
 	}
 
 	// 方法3でポインタを取得
-	fmt.Println("\n--- 方法3（ポインタアクセス）---")
-	middleMapPtr := &slice[middleIndex]
-	fmt.Printf("ポインタで取得したmap: %v\n", *middleMapPtr)
+	msg.Fprintln(out, "\n--- 方法3（ポインタアクセス）---")
+	middleMapPtr := sliceaccess.TrackRef(slice, middleIndex)
+	msg.Fprintf(out, "ポインタで取得したmap: %v\n", *middleMapPtr)
 
 	// ここでsliceを変更してみる
-	fmt.Println("\n--- sliceを変更 ---")
+	msg.Fprintln(out, "\n--- sliceを変更 ---")
 	// appendでsliceを拡張（内部配列が再割り当てされる可能性）
 	slice = append(slice, map[string]int{"new": 999})
-	fmt.Printf("append後のslice: %v\n", slice)
-	fmt.Printf("sliceの長さ: %d\n", len(slice))
+	msg.Fprintf(out, "append後のslice: %v\n", slice)
+	msg.Fprintf(out, "sliceの長さ: %d\n", len(slice))
 
 	// ポインタが指している先を確認
-	fmt.Printf("ポインタが指している先: %v\n", *middleMapPtr)
+	msg.Fprintf(out, "ポインタが指している先: %v\n", *middleMapPtr)
 
 	// さらに危険な例：sliceの先頭に要素を挿入
-	fmt.Println("\n--- より危険な例：sliceの先頭に要素を挿入 ---")
+	msg.Fprintln(out, "\n--- より危険な例：sliceの先頭に要素を挿入 ---")
 	originalSlice := slice
 	slice = append([]map[string]int{{"inserted": 888}}, slice...)
-	fmt.Printf("先頭挿入後のslice: %v\n", slice)
-	fmt.Printf("元のslice: %v\n", originalSlice)
-	fmt.Printf("ポインタが指している先: %v\n", *middleMapPtr)
+	msg.Fprintf(out, "先頭挿入後のslice: %v\n", slice)
+	msg.Fprintf(out, "元のslice: %v\n", originalSlice)
+	if err := sliceaccess.CheckRef(middleMapPtr, slice); err != nil {
+		msg.Fprintf(out, "⚠️  実行時検出: %v\n", err)
+	}
+	msg.Fprintf(out, "ポインタが指している先: %v\n", *middleMapPtr)
 
 	// 方法2と方法3の違いを明確に示す
-	fmt.Println("\n--- 方法2と方法3の違い ---")
-	fmt.Println("方法2: 毎回slice[middleIndex]を計算するため、sliceが変更されても正しい要素を取得")
-	fmt.Println("方法3: ポインタを保持するため、sliceが変更されると古いメモリ位置を参照する可能性")
+	msg.Fprintln(out, "\n--- 方法2と方法3の違い ---")
+	msg.Fprintln(out, "方法2: 毎回slice[middleIndex]を計算するため、sliceが変更されても正しい要素を取得")
+	msg.Fprintln(out, "方法3: ポインタを保持するため、sliceが変更されると古いメモリ位置を参照する可能性")
 
 	// 実際に方法2で再計算してみる
 	newMiddleIndex := len(slice) / 2
-	fmt.Printf("新しい真ん中のインデックス: %d\n", newMiddleIndex)
-	fmt.Printf("新しい真ん中の要素: %v\n", slice[newMiddleIndex])
+	msg.Fprintf(out, "新しい真ん中のインデックス: %d\n", newMiddleIndex)
+	msg.Fprintf(out, "新しい真ん中の要素: %v\n", slice[newMiddleIndex])
 }
 
 // より詳細な危険性の例
 func demonstrateDetailedDanger() {
-	fmt.Println("\n=== より詳細な危険性の例 ===")
+	msg.Fprintln(out, "\n=== より詳細な危険性の例 ===")
 
 	// 初期slice
 	slice := make([]map[string]int, 3)
 	for i := 0; i < 3; i++ {
 		slice[i] = map[string]int{"value": i * 10}
 	}
-	fmt.Printf("初期slice: %v\n", slice)
+	msg.Fprintf(out, "初期slice: %v\n", slice)
 
 	// 真ん中の要素のポインタを取得
-	middlePtr := &slice[1]
-	fmt.Printf("真ん中の要素のポインタ: %p, 値: %v\n", middlePtr, *middlePtr)
+	middlePtr := sliceaccess.TrackRef(slice, 1)
+	msg.Fprintf(out, "真ん中の要素のポインタ: %p, 値: %v\n", middlePtr, *middlePtr)
 
 	// sliceを大きく拡張（内部配列が再割り当てされる）
-	fmt.Println("\nsliceを大きく拡張...")
+	msg.Fprintln(out, "\nsliceを大きく拡張...")
 	slice = append(slice, make([]map[string]int, 1000)...)
-	fmt.Printf("拡張後のslice長: %d\n", len(slice))
-	fmt.Printf("元のポインタが指している先: %p, 値: %v\n", middlePtr, *middlePtr)
-	fmt.Printf("新しいslice[1]: %v\n", slice[1])
+	msg.Fprintf(out, "拡張後のslice長: %d\n", len(slice))
+
+	// -tags slicedebug でビルドすると、古い参照の使用を実行時に検出する
+	if err := sliceaccess.CheckRef(middlePtr, slice); err != nil {
+		msg.Fprintf(out, "⚠️  実行時検出: %v\n", err)
+	}
+	msg.Fprintf(out, "元のポインタが指している先: %p, 値: %v\n", middlePtr, *middlePtr)
+	msg.Fprintf(out, "新しいslice[1]: %v\n", slice[1])
 
 	// ポインタが指している先と実際のslice[1]が異なることを確認
 	if middlePtr != &slice[1] {
-		fmt.Println("⚠️  危険: ポインタが古いメモリ位置を指しています！")
-		fmt.Printf("ポインタのアドレス: %p\n", middlePtr)
-		fmt.Printf("実際のslice[1]のアドレス: %p\n", &slice[1])
+		msg.Fprintln(out, "⚠️  危険: ポインタが古いメモリ位置を指しています！")
+		msg.Fprintf(out, "ポインタのアドレス: %p\n", middlePtr)
+		msg.Fprintf(out, "実際のslice[1]のアドレス: %p\n", &slice[1])
+	}
+}
+
+// ハンドルを使えば再割り当て後も正しい要素を参照できることを示す関数
+func demonstrateStableHandles() {
+	msg.Fprintln(out, "\n=== ハンドルによる安全な参照 ===")
+
+	stable := sliceaccess.NewStableSlice[map[string]int](3)
+	for i := 0; i < 3; i++ {
+		stable.Append(map[string]int{"value": i * 10})
+	}
+
+	// 真ん中の要素のハンドルを取得（ポインタではなくインデックス + 世代）
+	middle, _ := stable.Middle()
+	value, _ := stable.Get(middle)
+	msg.Fprintf(out, "真ん中の要素: %v\n", value)
+
+	// 内部配列が再割り当てされるまで拡張
+	msg.Fprintln(out, "\nsliceを大きく拡張...")
+	for i := 0; i < 1000; i++ {
+		stable.Append(nil)
+	}
+	value, err := stable.Get(middle)
+	msg.Fprintf(out, "拡張後の長さ: %d\n", stable.Len())
+	msg.Fprintf(out, "ハンドルが指す要素: %v, エラー: %v\n", value, err)
+	msg.Fprintln(out, "✅ ハンドルは常に現在の内部配列から要素を引き直す")
+
+	// 要素を削除すると、古いハンドルはエラーを返す
+	msg.Fprintln(out, "\n要素を削除...")
+	_ = stable.Remove(middle)
+	if _, err := stable.Get(middle); errors.Is(err, sliceaccess.ErrStaleHandle) {
+		msg.Fprintf(out, "✅ 削除済みの要素へのアクセスはエラー: %v\n", err)
 	}
 }
 
 // メモリ管理の仕組みを詳しく説明する関数
 func explainMemoryManagement() {
-	fmt.Println("\n=== なぜ古いポインタのままになってしまうのか？ ===")
+	msg.Fprintln(out, "\n=== なぜ古いポインタのままになってしまうのか？ ===")
 
 	// 1. 初期状態の確認
-	fmt.Println("\n--- 1. 初期状態 ---")
+	msg.Fprintln(out, "\n--- 1. 初期状態 ---")
 	slice := make([]map[string]int, 3)
 	for i := 0; i < 3; i++ {
 		slice[i] = map[string]int{"id": i, "value": i * 10}
 	}
 
-	fmt.Printf("初期slice: %v\n", slice)
-	fmt.Printf("sliceのアドレス: %p\n", &slice)
-	fmt.Printf("slice[0]のアドレス: %p\n", &slice[0])
-	fmt.Printf("slice[1]のアドレス: %p\n", &slice[1])
-	fmt.Printf("slice[2]のアドレス: %p\n", &slice[2])
+	msg.Fprintf(out, "初期slice: %v\n", slice)
+	msg.Fprintf(out, "sliceのアドレス: %p\n", &slice)
+	msg.Fprintf(out, "slice[0]のアドレス: %p\n", &slice[0])
+	msg.Fprintf(out, "slice[1]のアドレス: %p\n", &slice[1])
+	msg.Fprintf(out, "slice[2]のアドレス: %p\n", &slice[2])
 
 	// 2. ポインタを取得
-	fmt.Println("\n--- 2. ポインタを取得 ---")
+	msg.Fprintln(out, "\n--- 2. ポインタを取得 ---")
 	middlePtr := &slice[1]
-	fmt.Printf("middlePtrの値（アドレス）: %p\n", middlePtr)
-	fmt.Printf("middlePtrが指す値: %v\n", *middlePtr)
+	msg.Fprintf(out, "middlePtrの値（アドレス）: %p\n", middlePtr)
+	msg.Fprintf(out, "middlePtrが指す値: %v\n", *middlePtr)
 
 	// 3. sliceの内部構造を確認
-	fmt.Println("\n--- 3. sliceの内部構造 ---")
-	fmt.Printf("sliceの長さ: %d\n", len(slice))
-	fmt.Printf("sliceの容量: %d\n", cap(slice))
-	fmt.Printf("sliceのデータポインタ: %p\n", &slice[0])
+	msg.Fprintln(out, "\n--- 3. sliceの内部構造 ---")
+	msg.Fprintf(out, "sliceの長さ: %d\n", len(slice))
+	msg.Fprintf(out, "sliceの容量: %d\n", cap(slice))
+	msg.Fprintf(out, "sliceのデータポインタ: %p\n", &slice[0])
 
 	// 4. 小さな拡張（容量内）
-	fmt.Println("\n--- 4. 小さな拡張（容量内）---")
+	msg.Fprintln(out, "\n--- 4. 小さな拡張（容量内）---")
 	slice = append(slice, map[string]int{"id": 3, "value": 30})
-	fmt.Printf("拡張後のslice: %v\n", slice)
-	fmt.Printf("sliceの長さ: %d, 容量: %d\n", len(slice), cap(slice))
-	fmt.Printf("slice[0]のアドレス: %p\n", &slice[0])
-	fmt.Printf("middlePtrの値: %p\n", middlePtr)
-	fmt.Printf("middlePtrが指す値: %v\n", *middlePtr)
-	fmt.Printf("slice[1]のアドレス: %p\n", &slice[1])
+	msg.Fprintf(out, "拡張後のslice: %v\n", slice)
+	msg.Fprintf(out, "sliceの長さ: %d, 容量: %d\n", len(slice), cap(slice))
+	msg.Fprintf(out, "slice[0]のアドレス: %p\n", &slice[0])
+	msg.Fprintf(out, "middlePtrの値: %p\n", middlePtr)
+	msg.Fprintf(out, "middlePtrが指す値: %v\n", *middlePtr)
+	msg.Fprintf(out, "slice[1]のアドレス: %p\n", &slice[1])
 
 	if middlePtr == &slice[1] {
-		fmt.Println("✅ ポインタは有効（容量内の拡張では再割り当てされない）")
+		msg.Fprintln(out, "✅ ポインタは有効（容量内の拡張では再割り当てされない）")
 	} else {
-		fmt.Println("❌ ポインタは無効（再割り当てが発生）")
+		msg.Fprintln(out, "❌ ポインタは無効（再割り当てが発生）")
 	}
 
 	// 5. 大きな拡張（容量を超える）
-	fmt.Println("\n--- 5. 大きな拡張（容量を超える）---")
-	fmt.Printf("現在の容量: %d\n", cap(slice))
+	msg.Fprintln(out, "\n--- 5. 大きな拡張（容量を超える）---")
+	msg.Fprintf(out, "現在の容量: %d\n", cap(slice))
 
 	// 容量を超える要素を追加
 	oldDataPtr := &slice[0]
 	slice = append(slice, make([]map[string]int, 10)...)
 
-	fmt.Printf("拡張後のslice長: %d, 容量: %d\n", len(slice), cap(slice))
-	fmt.Printf("古いデータポインタ: %p\n", oldDataPtr)
-	fmt.Printf("新しいslice[0]のアドレス: %p\n", &slice[0])
-	fmt.Printf("middlePtrの値: %p\n", middlePtr)
-	fmt.Printf("middlePtrが指す値: %v\n", *middlePtr)
-	fmt.Printf("新しいslice[1]のアドレス: %p\n", &slice[1])
+	msg.Fprintf(out, "拡張後のslice長: %d, 容量: %d\n", len(slice), cap(slice))
+	msg.Fprintf(out, "古いデータポインタ: %p\n", oldDataPtr)
+	msg.Fprintf(out, "新しいslice[0]のアドレス: %p\n", &slice[0])
+	msg.Fprintf(out, "middlePtrの値: %p\n", middlePtr)
+	msg.Fprintf(out, "middlePtrが指す値: %v\n", *middlePtr)
+	msg.Fprintf(out, "新しいslice[1]のアドレス: %p\n", &slice[1])
 
 	if middlePtr == &slice[1] {
-		fmt.Println("✅ ポインタは有効")
+		msg.Fprintln(out, "✅ ポインタは有効")
 	} else {
-		fmt.Println("❌ ポインタは無効（再割り当てが発生）")
-		fmt.Println("   → 古いメモリ位置を指し続けている")
+		msg.Fprintln(out, "❌ ポインタは無効（再割り当てが発生）")
+		msg.Fprintln(out, "   → 古いメモリ位置を指し続けている")
 	}
 
 	// 6. なぜポインタが更新されないのかを説明
-	fmt.Println("\n--- 6. なぜポインタが更新されないのか？ ---")
-	fmt.Println("Goのsliceは以下の3つの要素で構成されています：")
-	fmt.Println("1. データポインタ（実際の配列の先頭アドレス）")
-	fmt.Println("2. 長さ（len）")
-	fmt.Println("3. 容量（cap）")
-	fmt.Println()
-	fmt.Println("ポインタ変数（middlePtr）は、特定のメモリアドレスを保持します。")
-	fmt.Println("sliceが再割り当てされると：")
-	fmt.Println("- 新しいメモリ領域にデータがコピーされる")
-	fmt.Println("- sliceのデータポインタが新しいアドレスを指す")
-	fmt.Println("- しかし、古いポインタ変数は古いアドレスを保持し続ける")
-	fmt.Println()
-	fmt.Println("これが「古いポインタのまま」になってしまう理由です。")
+	msg.Fprintln(out, "\n--- 6. なぜポインタが更新されないのか？ ---")
+	msg.Fprintln(out, "Goのsliceは以下の3つの要素で構成されています：")
+	msg.Fprintln(out, "1. データポインタ（実際の配列の先頭アドレス）")
+	msg.Fprintln(out, "2. 長さ（len）")
+	msg.Fprintln(out, "3. 容量（cap）")
+	fmt.Fprintln(out)
+	msg.Fprintln(out, "ポインタ変数（middlePtr）は、特定のメモリアドレスを保持します。")
+	msg.Fprintln(out, "sliceが再割り当てされると：")
+	msg.Fprintln(out, "- 新しいメモリ領域にデータがコピーされる")
+	msg.Fprintln(out, "- sliceのデータポインタが新しいアドレスを指す")
+	msg.Fprintln(out, "- しかし、古いポインタ変数は古いアドレスを保持し続ける")
+	fmt.Fprintln(out)
+	msg.Fprintln(out, "これが「古いポインタのまま」になってしまう理由です。")
 
 	// 7. 安全な方法との比較
-	fmt.Println("\n--- 7. 安全な方法（方法2）との比較 ---")
-	fmt.Println("方法2では：")
-	fmt.Println("- ポインタを保持しない")
-	fmt.Println("- 毎回 slice[middleIndex] を計算")
-	fmt.Println("- sliceが変更されても、常に最新の位置を参照")
-	fmt.Println("- メモリ再割り当ての影響を受けない")
+	msg.Fprintln(out, "\n--- 7. 安全な方法（方法2）との比較 ---")
+	msg.Fprintln(out, "方法2では：")
+	msg.Fprintln(out, "- ポインタを保持しない")
+	msg.Fprintln(out, "- 毎回 slice[middleIndex] を計算")
+	msg.Fprintln(out, "- sliceが変更されても、常に最新の位置を参照")
+	msg.Fprintln(out, "- メモリ再割り当ての影響を受けない")
 
 	// 実際に比較してみる
-	fmt.Println("\n実際の比較：")
+	msg.Fprintln(out, "\n実際の比較：")
 	newMiddleIndex := len(slice) / 2
-	fmt.Printf("方法2で計算した真ん中の要素: %v\n", slice[newMiddleIndex])
-	fmt.Printf("方法3のポインタが指す要素: %v\n", *middlePtr)
+	msg.Fprintf(out, "方法2で計算した真ん中の要素: %v\n", slice[newMiddleIndex])
+	msg.Fprintf(out, "方法3のポインタが指す要素: %v\n", *middlePtr)
 
 	// mapの比較は直接できないので、アドレスで比較
 	if &slice[newMiddleIndex] == middlePtr {
-		fmt.Println("✅ 同じメモリ位置（安全）")
+		msg.Fprintln(out, "✅ 同じメモリ位置（安全）")
 	} else {
-		fmt.Println("❌ 異なるメモリ位置（危険な状態）")
-		fmt.Printf("方法2のアドレス: %p\n", &slice[newMiddleIndex])
-		fmt.Printf("方法3のアドレス: %p\n", middlePtr)
+		msg.Fprintln(out, "❌ 異なるメモリ位置（危険な状態）")
+		msg.Fprintf(out, "方法2のアドレス: %p\n", &slice[newMiddleIndex])
+		msg.Fprintf(out, "方法3のアドレス: %p\n", middlePtr)
 	}
+
+	// 8. チャンク分割による解決策
+	msg.Fprintln(out, "\n--- 8. チャンク分割による解決策 ---")
+	msg.Fprintln(out, "連続した1つの配列にこだわらなければ、再割り当ては避けられます。")
+	msg.Fprintln(out, "ChunkedSliceは固定サイズのチャンクを追加して伸びるため、要素は移動しません。")
+	chunked := sliceaccess.NewChunkedSlice[map[string]int](4)
+	for i := 0; i < 3; i++ {
+		chunked.Append(map[string]int{"id": i, "value": i * 10})
+	}
+	chunkedPtr, _ := chunked.Middle()
+	msg.Fprintf(out, "真ん中の要素のアドレス: %p, 値: %v\n", chunkedPtr, *chunkedPtr)
+	for i := 0; i < 10; i++ {
+		chunked.Append(map[string]int{"id": 3 + i})
+		chunked.PushFront(map[string]int{"id": -1 - i})
+	}
+	msg.Fprintf(out, "Append/PushFront後の長さ: %d\n", chunked.Len())
+	if chunked.At(11) == chunkedPtr {
+		msg.Fprintln(out, "✅ 同じメモリ位置（要素は移動していない）")
+		msg.Fprintf(out, "ポインタが指す値: %v\n", *chunkedPtr)
+	}
+
+	// 9. GrowthTracer で再割り当てを記録
+	msg.Fprintln(out, "\n--- 9. 再割り当ての記録 ---")
+	msg.Fprintln(out, "手順4と5の append を GrowthTracer 経由でやり直し、再割り当てをすべて記録します。")
+	tracer := sliceaccess.NewGrowthTracer(make([]map[string]int, 3))
+	tracer.OnGrowth(func(g sliceaccess.Growth) {
+		msg.Fprintf(out, "再割り当て: 容量 %d → %d (x%.2f), データポインタ %#x → %#x, コピー %d bytes\n",
+			g.OldCap, g.NewCap, g.Factor, g.OldData, g.NewData, g.BytesCopied)
+	})
+	tracer.Append(map[string]int{"id": 3, "value": 30})
+	tracer.Append(make([]map[string]int, 10)...)
+	msg.Fprintf(out, "再割り当ての回数: %d\n", len(tracer.Growths()))
+
+	elemType := reflect.TypeFor[map[string]int]()
+	msg.Fprintf(out, "\n%s（%d bytes、ポインタ: %v）のsliceに1要素ずつ append したときの容量の伸び方:\n", elemType, elemType.Size(), true)
+	sliceaccess.WriteGrowthTable(out, sliceaccess.GrowthCurve(elemType, 1024))
+	msg.Fprintln(out, "容量に空きがある間は append しても再割り当ては起きず、既存のポインタも有効なままです。")
+	msg.Fprintln(out, "任意の要素サイズの表は growth サブコマンドで確認できます。")
 }
 
 // 実際の使用パターンを検証する関数
-func testRealWorldUsage() {
-	fmt.Println("\n=== 実際の使用パターンの検証 ===")
+func testRealWorldUsage(opts options) {
+	msg.Fprintln(out, "\n=== 実際の使用パターンの検証 ===")
 
-	// 実際の使用場面をシミュレート
-	slice := createLargeSlice(1000)
+	// 実際の使用場面をシミュレート（-data-seed の指定があれば -keys などで生成したデータ）
+	slice, err := opts.largeSlice(1000)
+	if err != nil {
+		msg.Fprintf(out, "エラー: %v\n", err)
+		return
+	}
 
-	fmt.Println("\n--- パターン1: 一度だけアクセス（最も一般的）---")
+	msg.Fprintln(out, "\n--- パターン1: 一度だけアクセス（最も一般的）---")
 	// この場合、ポインタアクセスは完全に安全
-	value1 := getValueWithPointer(slice, "key1")
-	value2 := getValueWithIndex(slice, "key1")
-	fmt.Printf("ポインタアクセス: %d\n", value1)
-	fmt.Printf("インデックスアクセス: %d\n", value2)
+	value1 := getValueWithPointer(slice, opts.key)
+	value2 := getValueWithIndex(slice, opts.key)
+	msg.Fprintf(out, "ポインタアクセス: %d\n", value1)
+	msg.Fprintf(out, "インデックスアクセス: %d\n", value2)
 
-	fmt.Println("\n--- パターン2: 複数回アクセス（sliceが変更されない場合）---")
+	msg.Fprintln(out, "\n--- パターン2: 複数回アクセス（sliceが変更されない場合）---")
 	// sliceが変更されない限り、ポインタアクセスは安全で高速
 	for i := 0; i < 5; i++ {
-		val := getValueWithPointer(slice, "key2")
-		fmt.Printf("アクセス%d回目: %d\n", i+1, val)
+		val := getValueWithPointer(slice, opts.key)
+		msg.Fprintf(out, "アクセス%d回目: %d\n", i+1, val)
 	}
 
-	fmt.Println("\n--- パターン3: 複数回アクセス（sliceが変更される場合）---")
+	msg.Fprintln(out, "\n--- パターン3: 複数回アクセス（sliceが変更される場合）---")
 	// この場合のみ危険
-	fmt.Println("sliceを変更しながらアクセス...")
+	msg.Fprintln(out, "sliceを変更しながらアクセス...")
 	for i := 0; i < 3; i++ {
-		val := getValueWithPointer(slice, "key3")
-		fmt.Printf("変更前アクセス%d回目: %d\n", i+1, val)
+		val := getValueWithPointer(slice, opts.key)
+		msg.Fprintf(out, "変更前アクセス%d回目: %d\n", i+1, val)
 
 		// sliceを変更
-		slice = append(slice, map[string]int{"key3": 999})
-		fmt.Printf("slice長: %d\n", len(slice))
+		slice = append(slice, map[string]int{opts.key: 999})
+		msg.Fprintf(out, "slice長: %d\n", len(slice))
 
-		valAfter := getValueWithPointer(slice, "key3")
-		fmt.Printf("変更後アクセス%d回目: %d\n", i+1, valAfter)
+		valAfter := getValueWithPointer(slice, opts.key)
+		msg.Fprintf(out, "変更後アクセス%d回目: %d\n", i+1, valAfter)
 	}
 
-	fmt.Println("\n--- パターン4: 高頻度アクセス（パフォーマンス重視）---")
+	msg.Fprintln(out, "\n--- パターン4: 高頻度アクセス（パフォーマンス重視）---")
 	// 大量のアクセスでパフォーマンスを比較
-	largeSlice := createLargeSlice(100000)
-
-	// ポインタアクセス（高速）
-	start := time.Now()
-	for i := 0; i < 10000; i++ {
-		_ = getValueWithPointer(largeSlice, "key1")
-	}
-	pointerTime := time.Since(start)
-
-	// インデックスアクセス（安全）
-	start = time.Now()
-	for i := 0; i < 10000; i++ {
-		_ = getValueWithIndex(largeSlice, "key1")
-	}
-	indexTime := time.Since(start)
-
-	fmt.Printf("ポインタアクセス: %v (10000回)\n", pointerTime)
-	fmt.Printf("インデックスアクセス: %v (10000回)\n", indexTime)
-	fmt.Printf("ポインタアクセスは %.2fx 高速\n", float64(indexTime)/float64(pointerTime))
-
-	fmt.Println("\n--- 結論 ---")
-	fmt.Println("✅ 一度だけアクセス: ポインタアクセスは安全で高速")
-	fmt.Println("✅ 複数回アクセス（slice変更なし）: ポインタアクセスは安全で高速")
-	fmt.Println("❌ 複数回アクセス（slice変更あり）: ポインタアクセスは危険")
-	fmt.Println("✅ 高頻度アクセス: ポインタアクセスは大幅に高速")
-	fmt.Println()
-	fmt.Println("実際の使用では、sliceが変更されないことが多いため、")
-	fmt.Println("ポインタアクセスの危険性は過大評価されている可能性があります。")
+	largeSlice, err := opts.largeSlice(opts.sizeOr(100000))
+	if err != nil {
+		msg.Fprintf(out, "エラー: %v\n", err)
+		return
+	}
+	cfg := opts.measureConfig(10000)
+
+	// ポインタアクセス（高速）とインデックスアクセス（安全）
+	results := measure.RunCases(cfg, opts.seed, []measure.Case{
+		{Name: "getValueWithPointer", Fn: func() { resultSink += getValueWithPointer(largeSlice, opts.key) }},
+		{Name: "getValueWithIndex", Fn: func() { resultSink += getValueWithIndex(largeSlice, opts.key) }},
+	})
+	pointerResult, indexResult := results[0], results[1]
+
+	printResult("ポインタアクセス", pointerResult)
+	printResult("インデックスアクセス", indexResult)
+	printComparison("ポインタアクセス", "インデックスアクセス", indexResult, pointerResult, cfg.Alpha)
+	record("realworld", "frequent", "map[string]int", len(largeSlice), indexResult, pointerResult)
+
+	msg.Fprintln(out, "\n--- 結論 ---")
+	msg.Fprintln(out, "✅ 一度だけアクセス: ポインタアクセスは安全で高速")
+	msg.Fprintln(out, "✅ 複数回アクセス（slice変更なし）: ポインタアクセスは安全で高速")
+	msg.Fprintln(out, "❌ 複数回アクセス（slice変更あり）: ポインタアクセスは危険")
+	msg.Fprintln(out, "✅ 高頻度アクセス: ポインタアクセスは大幅に高速")
+	fmt.Fprintln(out)
+	msg.Fprintln(out, "実際の使用では、sliceが変更されないことが多いため、")
+	msg.Fprintln(out, "ポインタアクセスの危険性は過大評価されている可能性があります。")
 }
 
 // ポインタを使用した値取得（危険だが高速）
@@ -499,7 +582,7 @@ func getValueWithPointer(slice []map[string]int, key string) int {
 		return 0
 	}
 	middleIndex := len(slice) / 2
-	middlePtr := &slice[middleIndex]
+	middlePtr := sliceaccess.TrackRef(slice, middleIndex)
 	if *middlePtr == nil {
 		return 0
 	}
@@ -528,74 +611,83 @@ func getValueWithIndex(slice []map[string]int, key string) int {
 }
 
 // 大きな要素と多数の要素でのパフォーマンス比較
-func testLargeElementsPerformance() {
-	fmt.Println("\n=== 大きな要素と多数の要素でのパフォーマンス比較 ===")
+func testLargeElementsPerformance(opts options) {
+	msg.Fprintln(out, "\n=== 大きな要素と多数の要素でのパフォーマンス比較 ===")
 
 	// 大きな要素を持つsliceを作成
-	fmt.Println("\n--- 大きな要素でのテスト ---")
-	largeSlice := createLargeStructSlice(100000)
-	fmt.Printf("大きな要素のslice作成完了: %d個\n", len(largeSlice))
+	msg.Fprintln(out, "\n--- 大きな要素でのテスト ---")
+	var largeSlice []LargeStruct
+	var err error
+	d, mem := measure.Section(func() { largeSlice, err = opts.largeStructSlice(opts.sizeOr(100000)) })
+	if err != nil {
+		msg.Fprintf(out, "エラー: %v\n", err)
+		return
+	}
+	msg.Fprintf(out, "大きな要素のslice作成完了: %d個\n", len(largeSlice))
+	printSection("作成時間", d, mem)
+	recordSection("large", "createLargeStructSlice", "LargeStruct", len(largeSlice), d, mem)
 
 	// パフォーマンス比較
-	fmt.Println("\n--- パフォーマンス比較（1000回アクセス）---")
+	msg.Fprintln(out, "\n--- パフォーマンス比較（1000回アクセス）---")
 
-	// ポインタアクセス
-	start := time.Now()
-	for i := 0; i < 1000; i++ {
-		_ = getLargeStructWithPointer(largeSlice)
-	}
-	pointerTime := time.Since(start)
+	cfg := opts.measureConfig(1000)
 
-	// インデックスアクセス
-	start = time.Now()
-	for i := 0; i < 1000; i++ {
-		_ = getLargeStructWithIndex(largeSlice)
-	}
-	indexTime := time.Since(start)
+	results := measure.RunCases(cfg, opts.seed, []measure.Case{
+		{Name: "getLargeStructWithPointer", Fn: func() { resultSink += getLargeStructWithPointer(largeSlice).ID }},
+		{Name: "getLargeStructWithIndex", Fn: func() { resultSink += getLargeStructWithIndex(largeSlice).ID }},
+	})
+	pointerResult, indexResult := results[0], results[1]
 
-	fmt.Printf("ポインタアクセス: %v\n", pointerTime)
-	fmt.Printf("インデックスアクセス: %v\n", indexTime)
-	fmt.Printf("ポインタアクセスは %.2fx 高速\n", float64(indexTime)/float64(pointerTime))
+	printResult("ポインタアクセス", pointerResult)
+	printResult("インデックスアクセス", indexResult)
+	printComparison("ポインタアクセス", "インデックスアクセス", indexResult, pointerResult, cfg.Alpha)
+	record("large", "middle", "LargeStruct", len(largeSlice), indexResult, pointerResult)
 
 	// メモリ使用量の比較
-	fmt.Println("\n--- メモリ使用量の比較 ---")
-	fmt.Printf("1つのLargeStructのサイズ: %d bytes\n", getStructSize())
-	fmt.Printf("slice全体の推定サイズ: %d MB\n", len(largeSlice)*getStructSize()/(1024*1024))
+	msg.Fprintln(out, "\n--- メモリ使用量の比較 ---")
+	msg.Fprintf(out, "1つのLargeStructのサイズ: %d bytes (unsafe.Sizeof)\n", getCopySize())
+	msg.Fprintf(out, "1つのLargeStructの実際のサイズ: %d bytes (参照先を含む)\n", getStructSize(&largeSlice[len(largeSlice)/2]))
+	printFieldSizes(&largeSlice[len(largeSlice)/2])
+	msg.Fprintf(out, "slice全体の推定サイズ: %d MB\n", memsize.DeepSize(largeSlice).Total()/(1024*1024))
 
 	// より詳細な分析
-	fmt.Println("\n--- 詳細分析 ---")
-	analyzeAccessPatterns(largeSlice)
+	msg.Fprintln(out, "\n--- 詳細分析 ---")
+	analyzeAccessPatterns(largeSlice, opts)
 }
 
 // 大きな構造体のsliceを作成
+// Metadata の "created" は全要素で共通の作成時刻です（並列版と結果を揃えるため）。
 func createLargeStructSlice(size int) []LargeStruct {
 	slice := make([]LargeStruct, size)
+	created := time.Now()
 
 	for i := 0; i < size; i++ {
-		slice[i] = LargeStruct{
-			ID:   i,
-			Name: fmt.Sprintf("Item_%d", i),
-			Data: [1000]int{},
-			Metadata: map[string]interface{}{
-				"created": time.Now(),
-				"version": i % 10,
-				"active":  i%2 == 0,
-			},
-			Values: make([]float64, 100),
-		}
-
-		// データを埋める
-		for j := 0; j < 1000; j++ {
-			slice[i].Data[j] = i * j
-		}
-		for j := 0; j < 100; j++ {
-			slice[i].Values[j] = float64(i) * float64(j) * 0.1
-		}
+		fillLargeStruct(&slice[i], i, created)
 	}
 
 	return slice
 }
 
+// fillLargeStruct は createLargeStructSlice の要素 i の内容を s に書き込みます
+func fillLargeStruct(s *LargeStruct, i int, created time.Time) {
+	s.ID = i
+	s.Name = fmt.Sprintf("Item_%d", i)
+	s.Metadata = map[string]interface{}{
+		"created": created,
+		"version": i % 10,
+		"active":  i%2 == 0,
+	}
+	s.Values = make([]float64, 100)
+
+	// データを埋める
+	for j := 0; j < 1000; j++ {
+		s.Data[j] = i * j
+	}
+	for j := 0; j < 100; j++ {
+		s.Values[j] = float64(i) * float64(j) * 0.1
+	}
+}
+
 // ポインタを使用した大きな構造体の取得
 func getLargeStructWithPointer(slice []LargeStruct) *LargeStruct {
 	if len(slice) == 0 {
@@ -615,67 +707,90 @@ func getLargeStructWithIndex(slice []LargeStruct) LargeStruct {
 }
 
 // 構造体のサイズを取得
-func getStructSize() int {
+// unsafe.Sizeof と違い、Name の文字列、Metadata のmap、Values の内部配列も含む
+func getStructSize(s *LargeStruct) int {
+	return int(memsize.DeepSize(s).Total())
+}
+
+// 値のコピーで複製されるサイズを取得
+// コピーされるのは構造体本体のみで、参照先は共有される
+func getCopySize() int {
 	var s LargeStruct
 	return int(unsafe.Sizeof(s))
 }
 
+// フィールドごとのサイズの内訳を表示
+func printFieldSizes(s *LargeStruct) {
+	report := memsize.DeepSize(s)
+	msg.Fprintln(out, "フィールドごとの内訳:")
+	for _, f := range report.Fields {
+		msg.Fprintf(out, "  %-8s 本体 %5d bytes, 参照先 %5d bytes\n", f.Name, f.Inline, f.Heap)
+	}
+}
+
 // アクセスパターンの詳細分析
-func analyzeAccessPatterns(slice []LargeStruct) {
-	fmt.Println("アクセスパターンの分析:")
+func analyzeAccessPatterns(slice []LargeStruct, opts options) {
+	msg.Fprintln(out, "アクセスパターンの分析:")
 
 	// 1. 一度だけアクセス
-	fmt.Println("\n1. 一度だけアクセス:")
-	start := time.Now()
-	ptr := getLargeStructWithPointer(slice)
-	_ = ptr.ID
-	pointerOnce := time.Since(start)
-
-	start = time.Now()
-	val := getLargeStructWithIndex(slice)
-	_ = val.ID
-	indexOnce := time.Since(start)
-
-	fmt.Printf("  ポインタ: %v\n", pointerOnce)
-	fmt.Printf("  インデックス: %v\n", indexOnce)
-	fmt.Printf("  ポインタは %.2fx 高速\n", float64(indexOnce)/float64(pointerOnce))
+	msg.Fprintln(out, "\n1. 一度だけアクセス:")
+	// 一度だけのアクセスなので -iterations は適用しない
+	onceCfg := opts.measureConfig(1)
+	onceCfg.Iterations = 1
+
+	once := measure.RunCases(onceCfg, opts.seed, []measure.Case{
+		{Name: "pointer/once", Fn: func() {
+			ptr := getLargeStructWithPointer(slice)
+			resultSink += ptr.ID
+		}},
+		{Name: "index/once", Fn: func() {
+			val := getLargeStructWithIndex(slice)
+			resultSink += val.ID
+		}},
+	})
+	pointerOnce, indexOnce := once[0], once[1]
+
+	printResult("  ポインタ", pointerOnce)
+	printResult("  インデックス", indexOnce)
+	printComparison("  ポインタ", "インデックス", indexOnce, pointerOnce, onceCfg.Alpha)
+	record("large", "once", "LargeStruct", len(slice), indexOnce, pointerOnce)
 
 	// 2. 複数回アクセス（同じ要素）
-	fmt.Println("\n2. 複数回アクセス（同じ要素）:")
-	iterations := 10000
-
-	start = time.Now()
-	for i := 0; i < iterations; i++ {
-		ptr := getLargeStructWithPointer(slice)
-		_ = ptr.ID
-	}
-	pointerMultiple := time.Since(start)
-
-	start = time.Now()
-	for i := 0; i < iterations; i++ {
-		val := getLargeStructWithIndex(slice)
-		_ = val.ID
-	}
-	indexMultiple := time.Since(start)
-
-	fmt.Printf("  ポインタ: %v (%d回)\n", pointerMultiple, iterations)
-	fmt.Printf("  インデックス: %v (%d回)\n", indexMultiple, iterations)
-	fmt.Printf("  ポインタは %.2fx 高速\n", float64(indexMultiple)/float64(pointerMultiple))
+	msg.Fprintln(out, "\n2. 複数回アクセス（同じ要素）:")
+	multipleCfg := opts.measureConfig(10000)
+
+	multiple := measure.RunCases(multipleCfg, opts.seed, []measure.Case{
+		{Name: "pointer/multiple", Fn: func() {
+			ptr := getLargeStructWithPointer(slice)
+			resultSink += ptr.ID
+		}},
+		{Name: "index/multiple", Fn: func() {
+			val := getLargeStructWithIndex(slice)
+			resultSink += val.ID
+		}},
+	})
+	pointerMultiple, indexMultiple := multiple[0], multiple[1]
+
+	printResult(msg.Sprintf("  ポインタ (%d回)", multipleCfg.Iterations), pointerMultiple)
+	printResult(msg.Sprintf("  インデックス (%d回)", multipleCfg.Iterations), indexMultiple)
+	printComparison("  ポインタ", "インデックス", indexMultiple, pointerMultiple, multipleCfg.Alpha)
+	record("large", "multiple", "LargeStruct", len(slice), indexMultiple, pointerMultiple)
 
 	// 3. メモリコピーの影響
-	fmt.Println("\n3. メモリコピーの影響:")
-	fmt.Printf("  構造体サイズ: %d bytes\n", getStructSize())
-	fmt.Printf("  ポインタアクセス: 8 bytes (ポインタのみ)\n")
-	fmt.Printf("  インデックスアクセス: %d bytes (構造体全体をコピー)\n", getStructSize())
-	fmt.Printf("  コピー量の差: %dx\n", getStructSize()/8)
+	msg.Fprintln(out, "\n3. メモリコピーの影響:")
+	msg.Fprintf(out, "  構造体サイズ: %d bytes\n", getCopySize())
+	msg.Fprintf(out, "  ポインタアクセス: 8 bytes (ポインタのみ)\n")
+	msg.Fprintf(out, "  インデックスアクセス: %d bytes (構造体全体をコピー)\n", getCopySize())
+	msg.Fprintf(out, "  コピー量の差: %dx\n", getCopySize()/8)
 
 	// 4. 結論
-	fmt.Println("\n4. 結論:")
-	if float64(indexMultiple)/float64(pointerMultiple) > 1.5 {
-		fmt.Println("  ✅ 大きな要素では、ポインタアクセスが大幅に高速")
-		fmt.Println("  ✅ メモリコピーのオーバーヘッドが大きい")
-		fmt.Println("  ✅ 要素が大きいほど、ポインタアクセスの優位性が増す")
+	// 1回の計測のばらつきではなく、U検定で有意に速いかどうかで判断する
+	msg.Fprintln(out, "\n4. 結論:")
+	if measure.Compare(indexMultiple, pointerMultiple, multipleCfg.Alpha).Faster() {
+		msg.Fprintln(out, "  ✅ 大きな要素では、ポインタアクセスが大幅に高速")
+		msg.Fprintln(out, "  ✅ メモリコピーのオーバーヘッドが大きい")
+		msg.Fprintln(out, "  ✅ 要素が大きいほど、ポインタアクセスの優位性が増す")
 	} else {
-		fmt.Println("  ⚠️  このサイズでは、差はそれほど大きくない")
+		msg.Fprintln(out, "  ⚠️  このサイズでは、統計的に有意な差は見られない")
 	}
 }
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"slice_practice/i18n"
+	"slice_practice/measure"
+)
+
+// printComparison は渡された有意水準で有意かどうかを判定する（DefaultConfig.Alpha ではない）
+func TestPrintComparisonAlpha(t *testing.T) {
+	baseline := measure.Result{Name: "base", Summary: measure.Summarize([]float64{10, 11, 12, 13, 14, 15, 16, 17})}
+	candidate := measure.Result{Name: "cand", Summary: measure.Summarize([]float64{8, 9, 10, 11, 12, 13, 14, 15})}
+	p := measure.Compare(baseline, candidate, 1).P
+	if p <= 0.001 || p >= 0.5 {
+		t.Fatalf("p = %v; the samples should give a moderate p-value", p)
+	}
+
+	savedOut, savedMsg := out, msg
+	defer func() { out, msg = savedOut, savedMsg }()
+	msg = i18n.NewPrinter(i18n.Ja)
+	for _, tt := range []struct {
+		alpha       float64
+		significant bool
+	}{
+		{p / 2, false},
+		{p * 2, true},
+	} {
+		var b strings.Builder
+		out = &b
+		printComparison("cand", "base", baseline, candidate, tt.alpha)
+		if got := strings.Contains(b.String(), "有意ではない"); got == tt.significant {
+			t.Errorf("alpha %v (p=%v): %q", tt.alpha, p, b.String())
+		}
+	}
+}
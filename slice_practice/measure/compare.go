@@ -0,0 +1,100 @@
+package measure
+
+import (
+	"math"
+	"slices"
+)
+
+// Comparison は2つの計測結果をMann-WhitneyのU検定で比較した結果です
+type Comparison struct {
+	U           float64 // U統計量
+	Z           float64 // 正規近似したときのz値
+	P           float64 // 両側p値
+	Significant bool    // P < alpha
+	Speedup     float64 // baseline の中央値 / candidate の中央値
+}
+
+// Faster は candidate が baseline より有意に速いかどうかを返します
+func (c Comparison) Faster() bool {
+	return c.Significant && c.Speedup > 1
+}
+
+// Compare は candidate が baseline と比べて速いか遅いかを、
+// 分布を仮定しないMann-WhitneyのU検定（同順位補正付きの正規近似）で判定します。
+func Compare(baseline, candidate Result, alpha float64) Comparison {
+	if alpha <= 0 {
+		alpha = DefaultConfig.Alpha
+	}
+	c := Comparison{P: 1}
+	if candidate.Median > 0 {
+		c.Speedup = baseline.Median / candidate.Median
+	}
+
+	a, b := baseline.Values, candidate.Values
+	n1, n2 := float64(len(a)), float64(len(b))
+	if n1 == 0 || n2 == 0 {
+		return c
+	}
+
+	// 2つの標本を合わせて順位を付ける（同順位は平均順位）
+	type ranked struct {
+		value    float64
+		baseline bool
+	}
+	all := make([]ranked, 0, len(a)+len(b))
+	for _, v := range a {
+		all = append(all, ranked{v, true})
+	}
+	for _, v := range b {
+		all = append(all, ranked{v, false})
+	}
+	slices.SortFunc(all, func(x, y ranked) int {
+		switch {
+		case x.value < y.value:
+			return -1
+		case x.value > y.value:
+			return 1
+		}
+		return 0
+	})
+
+	var rankSum, tieTerm float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2 // 順位は1始まり
+		for k := i; k < j; k++ {
+			if all[k].baseline {
+				rankSum += rank
+			}
+		}
+		t := float64(j - i)
+		tieTerm += t*t*t - t
+		i = j
+	}
+
+	c.U = rankSum - n1*(n1+1)/2
+	mean := n1 * n2 / 2
+	n := n1 + n2
+	variance := n1 * n2 / 12 * ((n + 1) - tieTerm/(n*(n-1)))
+	if variance <= 0 {
+		return c
+	}
+
+	// 連続性補正
+	diff := c.U - mean
+	switch {
+	case diff > 0.5:
+		diff -= 0.5
+	case diff < -0.5:
+		diff += 0.5
+	default:
+		diff = 0
+	}
+	c.Z = diff / math.Sqrt(variance)
+	c.P = math.Erfc(math.Abs(c.Z) / math.Sqrt2)
+	c.Significant = c.P < alpha
+	return c
+}
@@ -0,0 +1,173 @@
+// Package measure は、アクセス方法の実行時間を複数回計測し、
+// 外れ値を除いた統計量と、方法間の差が統計的に有意かどうかを求めます。
+package measure
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+// Config は計測の設定です
+type Config struct {
+	Runs       int     // 計測の回数（標本の大きさ）
+	Iterations int     // 1回の計測で fn を呼ぶ回数
+	Alpha      float64 // 有意水準
+}
+
+// DefaultConfig は runPerformanceTest などで使うデフォルトの設定です
+var DefaultConfig = Config{Runs: 30, Iterations: 1000, Alpha: 0.05}
+
+// Result は1つのアクセス方法の計測結果です
+type Result struct {
+	Name       string
+	Iterations int
+	Summary
+
+	// Mem は全計測を通したメモリ割り当てとGCの合計です
+	Mem MemStats
+}
+
+// Run は fn を cfg.Iterations 回呼ぶ計測を cfg.Runs 回繰り返し、
+// 1回あたりの時間（ns/op）の統計量とメモリ統計を返します。
+// runtime.ReadMemStats は各計測の前後、タイマーの外側で呼びます。
+func Run(name string, cfg Config, fn func()) Result {
+	cfg = cfg.withDefaults()
+	samples := make([]float64, cfg.Runs)
+	var mem MemStats
+	for r := range samples {
+		before := readMem()
+		start := time.Now()
+		for i := 0; i < cfg.Iterations; i++ {
+			fn()
+		}
+		samples[r] = float64(time.Since(start)) / float64(cfg.Iterations)
+		mem.add(before.since(readMem(), uint64(cfg.Iterations)))
+	}
+	return Result{Name: name, Iterations: cfg.Iterations, Summary: Summarize(samples), Mem: mem}
+}
+
+func (c Config) withDefaults() Config {
+	if c.Runs <= 0 {
+		c.Runs = DefaultConfig.Runs
+	}
+	if c.Iterations <= 0 {
+		c.Iterations = DefaultConfig.Iterations
+	}
+	if c.Alpha <= 0 {
+		c.Alpha = DefaultConfig.Alpha
+	}
+	return c
+}
+
+// Summary は外れ値を除いた標本の統計量です（単位は ns/op）
+type Summary struct {
+	N        int // 外れ値を除いた標本の大きさ
+	Outliers int // 除外した外れ値の数
+	Mean     float64
+	Median   float64
+	StdDev   float64
+	CILow    float64 // 平均の95%信頼区間の下限
+	CIHigh   float64 // 平均の95%信頼区間の上限
+
+	// Values は外れ値を除いた標本です（Compare で使用）
+	Values []float64
+}
+
+// Summarize はTukeyの方法（四分位範囲の1.5倍）で外れ値を除き、統計量を計算します
+func Summarize(samples []float64) Summary {
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	if len(sorted) == 0 {
+		return Summary{}
+	}
+
+	// 外れ値の除外
+	q1, q3 := quantile(sorted, 0.25), quantile(sorted, 0.75)
+	iqr := q3 - q1
+	low, high := q1-1.5*iqr, q3+1.5*iqr
+	kept := make([]float64, 0, len(sorted))
+	for _, v := range sorted {
+		if v >= low && v <= high {
+			kept = append(kept, v)
+		}
+	}
+
+	s := Summary{
+		N:        len(kept),
+		Outliers: len(sorted) - len(kept),
+		Median:   quantile(kept, 0.5),
+		Values:   kept,
+	}
+	for _, v := range kept {
+		s.Mean += v
+	}
+	s.Mean /= float64(len(kept))
+	if len(kept) > 1 {
+		var sq float64
+		for _, v := range kept {
+			sq += (v - s.Mean) * (v - s.Mean)
+		}
+		s.StdDev = math.Sqrt(sq / float64(len(kept)-1))
+	}
+	margin := tQuantile975(len(kept)-1) * s.StdDev / math.Sqrt(float64(len(kept)))
+	s.CILow, s.CIHigh = s.Mean-margin, s.Mean+margin
+	return s
+}
+
+// quantile はソート済みの標本のq分位点を線形補間で求めます
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	frac := pos - float64(lower)
+	return sorted[lower] + (sorted[upper]-sorted[lower])*frac
+}
+
+// t分布の97.5%点（自由度1〜30）
+var tTable = [...]float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// tQuantile975 は自由度dfのt分布の97.5%点を返します。30を超える場合は正規分布で近似します。
+func tQuantile975(df int) float64 {
+	if df < 1 {
+		return 0
+	}
+	if df <= len(tTable) {
+		return tTable[df-1]
+	}
+	return 1.96
+}
+
+// Case は RunCases で計測する1つのアクセス方法です
+type Case struct {
+	Name string
+	Fn   func()
+}
+
+// RunCases は cases をそれぞれ Run で計測し、cases と同じ順序で結果を返します。
+// seed が0でなければ計測の順序をシャッフルし、先に計測した方法が
+// キャッシュやGCの状態で有利になる偏りを減らします。
+func RunCases(cfg Config, seed uint64, cases []Case) []Result {
+	order := make([]int, len(cases))
+	for i := range order {
+		order[i] = i
+	}
+	if seed != 0 {
+		r := rand.New(rand.NewPCG(seed, seed))
+		r.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	}
+
+	results := make([]Result, len(cases))
+	for _, i := range order {
+		results[i] = Run(cases[i].Name, cfg, cases[i].Fn)
+	}
+	return results
+}
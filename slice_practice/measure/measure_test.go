@@ -0,0 +1,39 @@
+package measure
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummarizeDropsOutliers(t *testing.T) {
+	samples := []float64{10, 11, 9, 10, 12, 8, 10, 11, 9, 1000}
+	s := Summarize(samples)
+	if s.Outliers != 1 || s.N != 9 {
+		t.Fatalf("N=%d Outliers=%d, want N=9 Outliers=1", s.N, s.Outliers)
+	}
+	if s.Median != 10 {
+		t.Errorf("Median = %v, want 10", s.Median)
+	}
+	if math.Abs(s.Mean-10) > 1e-9 {
+		t.Errorf("Mean = %v, want 10", s.Mean)
+	}
+	if !(s.CILow < s.Mean && s.Mean < s.CIHigh) {
+		t.Errorf("CI [%v, %v] does not contain mean %v", s.CILow, s.CIHigh, s.Mean)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	slow := Result{Summary: Summarize([]float64{20, 21, 22, 23, 24, 25, 26, 27, 28, 29})}
+	fast := Result{Summary: Summarize([]float64{10, 11, 12, 13, 14, 15, 16, 17, 18, 19})}
+
+	c := Compare(slow, fast, 0.05)
+	// 完全に分離した標本では U = n1*n2、p値は十分に小さい
+	if c.U != 100 || !c.Faster() {
+		t.Errorf("Compare(slow, fast) = %+v, want U=100 and Faster", c)
+	}
+
+	same := Compare(slow, slow, 0.05)
+	if same.Significant || same.P != 1 {
+		t.Errorf("Compare(slow, slow) = %+v, want P=1 and not significant", same)
+	}
+}
@@ -0,0 +1,80 @@
+package measure
+
+import (
+	"runtime"
+	"time"
+)
+
+// MemStats は計測区間のメモリ割り当てとGCの差分です
+type MemStats struct {
+	Bytes    uint64        // 割り当てたバイト数
+	Allocs   uint64        // 割り当て回数
+	GCCycles uint32        // 完了したGCの回数
+	GCPause  time.Duration // GCによる停止時間の合計
+	Ops      uint64        // 区間内で実行した操作の回数
+}
+
+// BytesPerOp は1操作あたりの割り当てバイト数を返します
+func (m MemStats) BytesPerOp() float64 {
+	if m.Ops == 0 {
+		return 0
+	}
+	return float64(m.Bytes) / float64(m.Ops)
+}
+
+// AllocsPerOp は1操作あたりの割り当て回数を返します
+func (m MemStats) AllocsPerOp() float64 {
+	if m.Ops == 0 {
+		return 0
+	}
+	return float64(m.Allocs) / float64(m.Ops)
+}
+
+// add は他の区間の差分を加算します
+func (m *MemStats) add(other MemStats) {
+	m.Bytes += other.Bytes
+	m.Allocs += other.Allocs
+	m.GCCycles += other.GCCycles
+	m.GCPause += other.GCPause
+	m.Ops += other.Ops
+}
+
+// memSnapshot は runtime.MemStats のうち差分を取る値です
+type memSnapshot struct {
+	totalAlloc uint64
+	mallocs    uint64
+	numGC      uint32
+	pauseTotal uint64
+}
+
+func readMem() memSnapshot {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return memSnapshot{
+		totalAlloc: ms.TotalAlloc,
+		mallocs:    ms.Mallocs,
+		numGC:      ms.NumGC,
+		pauseTotal: ms.PauseTotalNs,
+	}
+}
+
+// since は before からの差分を返します
+func (before memSnapshot) since(after memSnapshot, ops uint64) MemStats {
+	return MemStats{
+		Bytes:    after.totalAlloc - before.totalAlloc,
+		Allocs:   after.mallocs - before.mallocs,
+		GCCycles: after.numGC - before.numGC,
+		GCPause:  time.Duration(after.pauseTotal - before.pauseTotal),
+		Ops:      ops,
+	}
+}
+
+// Section は fn を1回実行し、その実行時間とメモリ統計を返します。
+// createLargeSlice のような計測ループ以外の区間に使います。
+func Section(fn func()) (time.Duration, MemStats) {
+	before := readMem()
+	start := time.Now()
+	fn()
+	elapsed := time.Since(start)
+	return elapsed, before.since(readMem(), 1)
+}
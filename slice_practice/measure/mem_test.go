@@ -0,0 +1,57 @@
+package measure
+
+import (
+	"testing"
+	"time"
+)
+
+var memSink []byte
+
+func TestSectionCountsAllocation(t *testing.T) {
+	const size = 1 << 20
+	_, m := Section(func() { memSink = make([]byte, size) })
+	if m.Bytes < size || m.Allocs < 1 || m.Ops != 1 {
+		t.Errorf("Section(make 1MB) = %+v; want Bytes >= %d and Allocs >= 1", m, size)
+	}
+	if m.BytesPerOp() != float64(m.Bytes) {
+		t.Errorf("BytesPerOp = %v; want %v", m.BytesPerOp(), float64(m.Bytes))
+	}
+	memSink = nil
+}
+
+func TestSectionWithoutAllocation(t *testing.T) {
+	sum := 0
+	_, m := Section(func() {
+		for i := range 1000 {
+			sum += i
+		}
+	})
+	// テストランナーの他のgoroutineが割り当てることがあるので、ごく小さな値までは許す
+	if m.Bytes > 1024 || m.Allocs > 4 {
+		t.Errorf("allocation-free section = %+v; want about zero", m)
+	}
+	if sum != 499500 {
+		t.Fatal(sum)
+	}
+}
+
+func TestMemStatsDelta(t *testing.T) {
+	before := memSnapshot{totalAlloc: 100, mallocs: 10, numGC: 1, pauseTotal: 1000}
+	after := memSnapshot{totalAlloc: 1100, mallocs: 30, numGC: 3, pauseTotal: 4000}
+	m := before.since(after, 10)
+	want := MemStats{Bytes: 1000, Allocs: 20, GCCycles: 2, GCPause: 3 * time.Microsecond, Ops: 10}
+	if m != want {
+		t.Errorf("since = %+v; want %+v", m, want)
+	}
+	if m.BytesPerOp() != 100 || m.AllocsPerOp() != 2 {
+		t.Errorf("per op = %v B, %v allocs; want 100, 2", m.BytesPerOp(), m.AllocsPerOp())
+	}
+
+	m.add(m)
+	if m.Bytes != 2000 || m.Allocs != 40 || m.GCCycles != 4 || m.Ops != 20 {
+		t.Errorf("add = %+v", m)
+	}
+	if (MemStats{}).BytesPerOp() != 0 || (MemStats{}).AllocsPerOp() != 0 {
+		t.Error("per-op values with zero Ops are not 0")
+	}
+}
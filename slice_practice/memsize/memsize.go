@@ -0,0 +1,307 @@
+// Package memsize は、値が実際に使っているメモリをリフレクションで計算します。
+//
+// unsafe.Sizeof は値そのもののサイズしか返さないため、文字列のバイト列、
+// sliceの内部配列、mapのバケットなど、ポインタの先にあるメモリは含まれません。
+// DeepSize はポインタをたどってこれらを合計し、共有されているメモリは一度だけ数えます。
+package memsize
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+	"unsafe"
+)
+
+// Report は DeepSize の結果です
+type Report struct {
+	Type   string
+	Inline int64   // 値そのもののサイズ（unsafe.Sizeof と同じ）
+	Heap   int64   // 値から参照されている、ポインタの先のメモリのサイズ
+	Fields []Field // 構造体の場合のフィールドごとの内訳
+}
+
+// Total は値そのものと参照先を合わせたサイズを返します
+func (r Report) Total() int64 {
+	return r.Inline + r.Heap
+}
+
+// Field は構造体の1フィールドの内訳です
+type Field struct {
+	Name   string
+	Inline int64 // 構造体の中でフィールドが占めるサイズ
+	Heap   int64 // フィールドから参照されているメモリのサイズ
+}
+
+// Total はフィールドと参照先を合わせたサイズを返します
+func (f Field) Total() int64 {
+	return f.Inline + f.Heap
+}
+
+// DeepSize は v が使っているメモリを、ポインタ、slice、map、文字列、
+// インターフェースをたどって計算します。v がポインタの場合は指している先の値を対象にします。
+// mapのサイズはランタイムの実装に基づく推定値です。
+func DeepSize(v any) Report {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return Report{}
+	}
+
+	s := &sizer{maps: make(map[uintptr]bool)}
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		// ポインタの先は Inline として数えるので、二重に数えないよう記録しておく
+		start := rv.Pointer()
+		end := start + rv.Type().Elem().Size()
+		s.counted.add(start, end)
+		s.scanned.add(start, end)
+		rv = rv.Elem()
+	} else {
+		// 参照先のアドレスを調べられるよう、アドレスを取れるコピーにする
+		c := reflect.New(rv.Type()).Elem()
+		c.Set(rv)
+		rv = c
+	}
+
+	r := Report{
+		Type:   rv.Type().String(),
+		Inline: int64(rv.Type().Size()),
+	}
+	if rv.Kind() != reflect.Struct {
+		r.Heap = s.heap(rv)
+		return r
+	}
+
+	for i := 0; i < rv.NumField(); i++ {
+		f := Field{
+			Name:   rv.Type().Field(i).Name,
+			Inline: int64(rv.Type().Field(i).Type.Size()),
+			Heap:   s.heap(rv.Field(i)),
+		}
+		r.Heap += f.Heap
+		r.Fields = append(r.Fields, f)
+	}
+	return r
+}
+
+// sizer は一度数えたメモリを記録しながら参照先をたどります。
+// heap に渡す Value はすべてアドレスを取れる（CanAddr）ものにしています。
+type sizer struct {
+	counted spans            // 数えたメモリ（sliceは容量分）
+	scanned spans            // 参照先をたどった値（sliceは長さ分）
+	maps    map[uintptr]bool // 数えたmap
+}
+
+// heap は v から参照されているメモリのサイズを返します（v 自身のサイズは含みません）
+func (s *sizer) heap(v reflect.Value) int64 {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return 0
+		}
+		return s.object(v.Type().Elem(), v.UnsafePointer())
+
+	case reflect.String:
+		if v.Len() == 0 {
+			return 0
+		}
+		start := uintptr(unsafe.Pointer(unsafe.StringData(v.String())))
+		return s.counted.add(start, start+uintptr(v.Len()))
+
+	case reflect.Slice:
+		if v.Cap() == 0 {
+			return 0
+		}
+		// 内部配列は容量分が確保されている。部分sliceが重なっていても、配列の各バイトは一度だけ数える
+		elem := v.Type().Elem()
+		start := v.Pointer()
+		size := s.counted.add(start, start+uintptr(v.Cap())*elem.Size())
+		if hasPointers(elem) {
+			for i := 0; i < v.Len(); i++ {
+				p := start + uintptr(i)*elem.Size()
+				if s.scanned.add(p, p+elem.Size()) > 0 {
+					size += s.heap(v.Index(i))
+				}
+			}
+		}
+		return size
+
+	case reflect.Array:
+		var size int64
+		if hasPointers(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				size += s.heap(v.Index(i))
+			}
+		}
+		return size
+
+	case reflect.Struct:
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += s.heap(v.Field(i))
+		}
+		return size
+
+	case reflect.Map:
+		if v.IsNil() || s.maps[v.Pointer()] {
+			return 0
+		}
+		s.maps[v.Pointer()] = true
+		size := mapOverhead(v.Type(), v.Len())
+		if hasPointers(v.Type().Key()) || hasPointers(v.Type().Elem()) {
+			// キーと値はアドレスを取れないので、コピーしてからたどる（参照先のアドレスは変わらない）
+			key := reflect.New(v.Type().Key()).Elem()
+			value := reflect.New(v.Type().Elem()).Elem()
+			iter := exported(v).MapRange()
+			for iter.Next() {
+				key.SetIterKey(iter)
+				value.SetIterValue(iter)
+				size += s.heap(key) + s.heap(value)
+			}
+		}
+		return size
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		v = exported(v)
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		// インターフェースの2ワード目は、ボックス化された値へのポインタか、ポインタ型の値そのもの
+		data := (*[2]unsafe.Pointer)(unsafe.Pointer(v.UnsafeAddr()))[1]
+		if isDirectIface(elem, data) {
+			return s.heap(elem)
+		}
+		// それ以外の値は別の領域に確保（ボックス化）される。同じボックスは一度だけ数える
+		return s.object(elem.Type(), data)
+	}
+
+	// 数値、bool、chan、funcなどは参照先をたどらない
+	return 0
+}
+
+// object は p にある t 型の値のメモリを数え、まだたどっていなければその参照先も数えます
+func (s *sizer) object(t reflect.Type, p unsafe.Pointer) int64 {
+	start := uintptr(p)
+	end := start + t.Size()
+	size := s.counted.add(start, end)
+	if hasPointers(t) && s.scanned.add(start, end) > 0 {
+		size += s.heap(reflect.NewAt(t, p).Elem())
+	}
+	return size
+}
+
+// exported はアドレスを取れる v を、非公開フィールド経由でも Set や MapRange の値を
+// コピーできる Value にして返します（同じメモリを指します）
+func exported(v reflect.Value) reflect.Value {
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// spans は重ならない区間 [start, end) の集合で、開始アドレス順に並んでいます
+type spans []span
+
+type span struct{ start, end uintptr }
+
+// add は [start, end) を集合に加え、新たに覆われたバイト数を返します。
+// 重なる区間や隣接する区間は1つにまとめます。
+func (s *spans) add(start, end uintptr) int64 {
+	if start >= end {
+		return 0
+	}
+	if n := len(*s); n == 0 || (*s)[n-1].end < start {
+		// 多くのメモリはアドレスの昇順にたどるので、末尾への追加を先に確認する
+		*s = append(*s, span{start, end})
+		return int64(end - start)
+	}
+	i := sort.Search(len(*s), func(i int) bool { return (*s)[i].end >= start })
+	merged := span{start, end}
+	var covered uintptr
+	j := i
+	for ; j < len(*s) && (*s)[j].start <= end; j++ {
+		r := (*s)[j]
+		covered += min(r.end, end) - max(r.start, start)
+		merged.start = min(merged.start, r.start)
+		merged.end = max(merged.end, r.end)
+	}
+	*s = slices.Replace(*s, i, j, merged)
+	return int64(end - start - covered)
+}
+
+// hasPointers は t の値がポインタの先のメモリを持ちうるかどうかを返します
+func hasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.String, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	case reflect.Array:
+		return t.Len() > 0 && hasPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDirectIface は v の値がボックス化されずに、インターフェースの2ワード目 data に直接格納されているかを返します。
+// 直接格納されるのはポインタ1つ分の型（ポインタ、map、chan、func、それだけを持つ構造体や配列）ですが、
+// 細かな条件はコンパイラのバージョンで異なるため、data が値そのものと一致するかで確かめます。
+// ボックスは変換のたびに新しく確保されるので、値が自分のボックスを指していることはありません。
+func isDirectIface(v reflect.Value, data unsafe.Pointer) bool {
+	if !pointerShaped(v.Type()) {
+		return false
+	}
+	return *(*unsafe.Pointer)(unsafe.Pointer(v.UnsafeAddr())) == data
+}
+
+// pointerShaped は t がポインタ1つ分のサイズで、その1ワードがポインタかどうかを返します
+func pointerShaped(t reflect.Type) bool {
+	if t.Size() != unsafe.Sizeof(uintptr(0)) {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return true
+	case reflect.Array:
+		return pointerShaped(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i).Type; f.Size() != 0 {
+				return pointerShaped(f)
+			}
+		}
+	}
+	return false
+}
+
+// mapのランタイム構造（Go 1.24以降のSwiss Table）に基づく定数
+const (
+	mapHeaderSize  = 48 // runtime/internal/maps.Map
+	mapGroupSlots  = 8  // 1グループあたりのスロット数
+	mapCtrlSize    = 8  // 1グループの制御ワード
+	mapTableSize   = 32 // runtime/internal/maps.table
+	mapMaxTableCap = 1024
+)
+
+// mapOverhead はmapのヘッダーとグループ（スロットの配列）のサイズを推定します。
+// 負荷率7/8でグループ数が2の冪に切り上げられることを前提にしています。
+func mapOverhead(t reflect.Type, length int) int64 {
+	slotSize := int64(t.Key().Size() + t.Elem().Size())
+	groupSize := mapCtrlSize + mapGroupSlots*slotSize
+	if length <= mapGroupSlots {
+		// 8要素以下はテーブルを持たず、1グループだけを直接持つ
+		if length == 0 {
+			return mapHeaderSize
+		}
+		return mapHeaderSize + groupSize
+	}
+
+	slots := int64(mapGroupSlots)
+	for slots*7/8 < int64(length) {
+		slots *= 2
+	}
+	groups := slots / mapGroupSlots
+	tables := (slots + mapMaxTableCap - 1) / mapMaxTableCap
+	// テーブルのディレクトリ（ポインタの配列）とテーブル本体
+	return mapHeaderSize + tables*(8+mapTableSize) + groups*groupSize
+}
@@ -0,0 +1,183 @@
+package memsize
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeepSize(t *testing.T) {
+	type item struct {
+		Name   string
+		Values []int64
+	}
+
+	v := item{Name: "abcd", Values: make([]int64, 2, 10)}
+	r := DeepSize(v)
+	if r.Inline != 40 {
+		t.Errorf("Inline = %d, want 40", r.Inline)
+	}
+	// 文字列4バイト + 内部配列（容量10 × 8バイト）
+	if r.Heap != 4+80 {
+		t.Errorf("Heap = %d, want 84", r.Heap)
+	}
+	if len(r.Fields) != 2 || r.Fields[0].Name != "Name" || r.Fields[1].Heap != 80 {
+		t.Errorf("Fields = %+v", r.Fields)
+	}
+}
+
+func TestDeepSizeSharedMemory(t *testing.T) {
+	shared := make([]byte, 100)
+	v := struct {
+		A, B []byte
+		P, Q *[64]byte
+	}{A: shared, B: shared}
+	v.P = new([64]byte)
+	v.Q = v.P
+
+	// 共有されている内部配列とポインタの先は一度だけ数える
+	if got := DeepSize(&v).Heap; got != 100+64 {
+		t.Errorf("Heap = %d, want %d", got, 100+64)
+	}
+}
+
+// ポインタとsliceが同じ内部配列を指していても、配列は一度だけ数える（順番によらない）
+func TestDeepSizePointerIntoSlice(t *testing.T) {
+	s := make([]int, 100)
+	v1 := struct {
+		P *int
+		S []int
+	}{&s[0], s}
+	v2 := struct {
+		S []int
+		P *int
+	}{s, &s[50]}
+	if got := DeepSize(v1).Heap; got != 800 {
+		t.Errorf("pointer first: Heap = %d, want 800", got)
+	}
+	if got := DeepSize(v2).Heap; got != 800 {
+		t.Errorf("slice first: Heap = %d, want 800", got)
+	}
+}
+
+// 同じ内部配列の部分sliceは、重なっていてもいなくても配列を一度だけ数える
+func TestDeepSizeSubslices(t *testing.T) {
+	s := make([]int64, 100)
+	tests := []struct {
+		name string
+		A, B []int64
+	}{
+		{"disjoint", s[:50], s[50:]},
+		{"overlapping", s[:60], s[40:]},
+		{"tail first", s[50:], s[:50]},
+	}
+	for _, tt := range tests {
+		v := struct{ A, B []int64 }{tt.A, tt.B}
+		if got := DeepSize(v).Heap; got != 800 {
+			t.Errorf("%s: Heap = %d, want 800", tt.name, got)
+		}
+	}
+
+	// 先の部分sliceが容量で配列全体を覆っていても、後の部分sliceの要素の参照先は数える
+	strs := []string{strings.Repeat("a", 10), strings.Repeat("b", 20), strings.Repeat("c", 30), strings.Repeat("d", 40)}
+	v := struct{ A, B []string }{strs[:2], strs[2:]}
+	if got, want := DeepSize(v).Heap, int64(4*16+10+20+30+40); got != want {
+		t.Errorf("string subslices: Heap = %d, want %d", got, want)
+	}
+}
+
+func TestDeepSizeMap(t *testing.T) {
+	var nilMap map[string]int
+	if got := DeepSize(nilMap).Heap; got != 0 {
+		t.Errorf("nil map: Heap = %d, want 0", got)
+	}
+
+	small := map[int]int{1: 1, 2: 2}
+	if got, want := DeepSize(small).Heap, mapOverhead(reflect.TypeOf(small), 2); got != want {
+		t.Errorf("small map: Heap = %d, want %d", got, want)
+	}
+
+	// 8要素を超えるとテーブルに分かれ、グループ数が2の冪に切り上げられる
+	large := make(map[int64]int64)
+	for i := range int64(100) {
+		large[i] = i
+	}
+	// 100要素 → 128スロット（16グループ × (8 + 8×16)）と1テーブル
+	if got, want := DeepSize(large).Heap, int64(mapHeaderSize+(8+mapTableSize)+16*(8+8*16)); got != want {
+		t.Errorf("large map: Heap = %d, want %d", got, want)
+	}
+
+	// キーの文字列と値の内部配列もたどり、値どうしで共有されている配列は一度だけ数える
+	shared := make([]byte, 64)
+	m := map[string][]byte{
+		strings.Repeat("k", 5): shared,
+		strings.Repeat("l", 7): shared[:10],
+	}
+	if got, want := DeepSize(m).Heap, mapOverhead(reflect.TypeOf(m), 2)+5+7+64; got != want {
+		t.Errorf("map with shared values: Heap = %d, want %d", got, want)
+	}
+
+	// 同じmapを2回参照しても一度だけ数える
+	v := struct{ A, B map[int]int }{small, small}
+	if got, want := DeepSize(v).Heap, mapOverhead(reflect.TypeOf(small), 2); got != want {
+		t.Errorf("shared map: Heap = %d, want %d", got, want)
+	}
+}
+
+func TestDeepSizeInterface(t *testing.T) {
+	type box struct{ A, B, C int64 }
+	n := int64(len(t.Name())) // 定数ではない値（定数は読み取り専用の領域に置かれることがある）
+
+	// ポインタ以外の値はボックス化される。同じインターフェースをコピーしてもボックスは共有される
+	x := any(box{A: n})
+	if got := DeepSize([]any{x, x}).Heap; got != 2*16+24 {
+		t.Errorf("shared box: Heap = %d, want %d", got, 2*16+24)
+	}
+	// 別々に変換すると別のボックスになる
+	if got := DeepSize([]any{any(box{A: n}), any(box{B: n})}).Heap; got != 2*16+2*24 {
+		t.Errorf("separate boxes: Heap = %d, want %d", got, 2*16+2*24)
+	}
+
+	// ポインタはインターフェースに直接格納され、指している先は他の参照と共有される
+	p := &box{A: n}
+	v := struct {
+		I any
+		P *box
+	}{p, p}
+	if got := DeepSize(v).Heap; got != 24 {
+		t.Errorf("pointer in interface: Heap = %d, want 24", got)
+	}
+
+	// ボックスの中のsliceの参照先もたどる
+	s := make([]int32, 10)
+	w := struct {
+		I any
+		S []int32
+	}{s[:5], s}
+	if got := DeepSize(w).Heap; got != 24+40 {
+		t.Errorf("slice in interface: Heap = %d, want %d", got, 24+40)
+	}
+
+	// 非公開フィールドのインターフェースやmapもたどれる
+	u := struct {
+		i any
+		m map[string]any
+	}{i: box{}, m: map[string]any{"k": p}}
+	want := int64(24) + mapOverhead(reflect.TypeOf(u.m), 1) + 1 + 24
+	if got := DeepSize(u).Heap; got != want {
+		t.Errorf("unexported fields: Heap = %d, want %d", got, want)
+	}
+}
+
+// 循環している参照でも止まり、各ノードを一度だけ数える
+func TestDeepSizeCycle(t *testing.T) {
+	type node struct {
+		Next *node
+		Val  int64
+	}
+	a, b := &node{}, &node{}
+	a.Next, b.Next = b, a
+	if got := DeepSize(a).Heap; got != 16 {
+		t.Errorf("Heap = %d, want 16", got)
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import "slice_practice/i18n"
+
+// init は表示文言の英語カタログを登録します。
+// キーは日本語の原文（前後の空白を除いたもの）です。
+func init() {
+	i18n.SetCatalog(i18n.En, map[string]string{
+		"=== 大きなsliceの真ん中のmap要素への安全で効率的なアクセス方法 ===": "=== Safe and efficient access to the middle map element of a large slice ===",
+		"作成したsliceのサイズ: %d":              "Created slice size: %d",
+		"作成時間":                           "Creation time",
+		"--- 方法1: 基本的な安全なアクセス ---":       "--- Method 1: basic safe access ---",
+		"エラー: %v":                        "Error: %v",
+		"真ん中のmapの%sの値: %v":               "Value of %s in the middle map: %v",
+		"--- 方法2: 効率的なアクセス ---":          "--- Method 2: efficient access ---",
+		"--- 方法3: ポインタを使用した効率的なアクセス ---": "--- Method 3: efficient access via pointer ---",
+		"--- 空のsliceでのテスト ---":           "--- Test with an empty slice ---",
+		"期待通りのエラー: %v":                   "Expected error: %v",
+		"--- 存在しないキーでのテスト ---":           "--- Test with a missing key ---",
+		"期待通りのエラー: キー %v がインデックス %d のmapに存在しません": "Expected error: key %v does not exist in the map at index %d",
+		"=== パフォーマンステスト ===":                     "=== Performance test ===",
+		"各方法のパフォーマンス比較（%d回実行 × %d回計測）:":          "Performance comparison of each method (%d calls × %d runs):",
+		"方法1 (安全なアクセス)":                          "Method 1 (safe access)",
+		"方法2 (効率的なアクセス)":                         "Method 2 (efficient access)",
+		"方法3 (ポインタアクセス)":                         "Method 3 (pointer access)",
+		"効率性の比較（Mann-WhitneyのU検定, 有意水準 %.2f）:":   "Efficiency comparison (Mann-Whitney U test, significance level %.2f):",
+		"方法2": "Method 2",
+		"方法1": "Method 1",
+		"方法3": "Method 3",
+		"%s: 中央値 %.2f ns/op, 平均 %.2f ns/op (95%%信頼区間 %.2f〜%.2f), 標準偏差 %.2f (n=%d, 外れ値 %d)": "%s: median %.2f ns/op, mean %.2f ns/op (95%% CI %.2f–%.2f), stddev %.2f (n=%d, outliers %d)",
+		"%s  メモリ: %.1f B/op, %.2f allocs/op, GC %d回 (停止 %v)":                               "%s  memory: %.1f B/op, %.2f allocs/op, GC %d cycles (pause %v)",
+		"%s: %v, 割り当て %.1f MB (%d回), GC %d回 (停止 %v)":                                       "%s: %v, allocated %.1f MB (%d allocs), GC %d cycles (pause %v)",
+		"%sと%sの差は有意ではない (%.2fx, p=%.4f)":                                                   "No significant difference between %s and %s (%.2fx, p=%.4f)",
+		"%sは%sより %.2fx 高速 (p=%.4f, 有意)":                                                    "%[1]s is %.2[3]fx faster than %[2]s (p=%.4[4]f, significant)",
+		"%sは%sより %.2fx 低速 (p=%.4f, 有意)":                                                    "%[1]s is %.2[3]fx slower than %[2]s (p=%.4[4]f, significant)",
+		"=== ポインタアクセスの危険性デモンストレーション ===":                                                   "=== Demonstrating the danger of pointer access ===",
+		"初期slice: %v":                   "Initial slice: %v",
+		"真ん中のインデックス: %d":                "Middle index: %d",
+		"--- 方法2（安全なアクセス）---":           "--- Method 2 (safe access) ---",
+		"方法2で取得した値: %d":                 "Value obtained with method 2: %d",
+		"--- 方法3（ポインタアクセス）---":          "--- Method 3 (pointer access) ---",
+		"ポインタで取得したmap: %v":              "Map obtained through the pointer: %v",
+		"--- sliceを変更 ---":              "--- Modifying the slice ---",
+		"append後のslice: %v":             "Slice after append: %v",
+		"sliceの長さ: %d":                  "Slice length: %d",
+		"ポインタが指している先: %v":               "Pointer target: %v",
+		"--- より危険な例：sliceの先頭に要素を挿入 ---": "--- A more dangerous case: inserting an element at the front ---",
+		"先頭挿入後のslice: %v":               "Slice after front insertion: %v",
+		"元のslice: %v":                   "Original slice: %v",
+		"⚠️  実行時検出: %v":                 "⚠️  Detected at runtime: %v",
+		"--- 方法2と方法3の違い ---":            "--- Difference between method 2 and method 3 ---",
+		"方法2: 毎回slice[middleIndex]を計算するため、sliceが変更されても正しい要素を取得": "Method 2: recomputes slice[middleIndex] every time, so it gets the correct element even after the slice changes",
+		"方法3: ポインタを保持するため、sliceが変更されると古いメモリ位置を参照する可能性":          "Method 3: holds a pointer, so it may refer to an old memory location after the slice changes",
+		"新しい真ん中のインデックス: %d":             "New middle index: %d",
+		"新しい真ん中の要素: %v":                 "New middle element: %v",
+		"=== より詳細な危険性の例 ===":            "=== A more detailed danger example ===",
+		"真ん中の要素のポインタ: %p, 値: %v":        "Pointer to the middle element: %p, value: %v",
+		"sliceを大きく拡張...":                "Growing the slice significantly...",
+		"拡張後のslice長: %d":                "Slice length after growth: %d",
+		"元のポインタが指している先: %p, 値: %v":      "Original pointer target: %p, value: %v",
+		"新しいslice[1]: %v":               "New slice[1]: %v",
+		"⚠️  危険: ポインタが古いメモリ位置を指しています！":  "⚠️  Danger: the pointer refers to an old memory location!",
+		"ポインタのアドレス: %p":                 "Pointer address: %p",
+		"実際のslice[1]のアドレス: %p":          "Actual address of slice[1]: %p",
+		"=== ハンドルによる安全な参照 ===":          "=== Safe references with handles ===",
+		"真ん中の要素: %v":                    "Middle element: %v",
+		"拡張後の長さ: %d":                    "Length after growth: %d",
+		"ハンドルが指す要素: %v, エラー: %v":        "Element referenced by the handle: %v, error: %v",
+		"✅ ハンドルは常に現在の内部配列から要素を引き直す":     "✅ A handle always resolves the element from the current backing array",
+		"要素を削除...":                      "Removing the element...",
+		"✅ 削除済みの要素へのアクセスはエラー: %v":       "✅ Accessing a removed element returns an error: %v",
+		"=== なぜ古いポインタのままになってしまうのか？ ===": "=== Why does the pointer stay stale? ===",
+		"--- 1. 初期状態 ---":               "--- 1. Initial state ---",
+		"sliceのアドレス: %p":                "Address of slice: %p",
+		"slice[0]のアドレス: %p":             "Address of slice[0]: %p",
+		"slice[1]のアドレス: %p":             "Address of slice[1]: %p",
+		"slice[2]のアドレス: %p":             "Address of slice[2]: %p",
+		"--- 2. ポインタを取得 ---":            "--- 2. Taking a pointer ---",
+		"middlePtrの値（アドレス）: %p":         "Value of middlePtr (address): %p",
+		"middlePtrが指す値: %v":             "Value pointed to by middlePtr: %v",
+		"--- 3. sliceの内部構造 ---":         "--- 3. Internal structure of the slice ---",
+		"sliceの容量: %d":                  "Slice capacity: %d",
+		"sliceのデータポインタ: %p":             "Slice data pointer: %p",
+		"--- 4. 小さな拡張（容量内）---":          "--- 4. Small growth (within capacity) ---",
+		"拡張後のslice: %v":                 "Slice after growth: %v",
+		"sliceの長さ: %d, 容量: %d":          "Slice length: %d, capacity: %d",
+		"middlePtrの値: %p":               "Value of middlePtr: %p",
+		"✅ ポインタは有効（容量内の拡張では再割り当てされない）": "✅ The pointer is valid (growth within capacity does not reallocate)",
+		"❌ ポインタは無効（再割り当てが発生）":          "❌ The pointer is invalid (reallocation happened)",
+		"--- 5. 大きな拡張（容量を超える）---":      "--- 5. Large growth (exceeding capacity) ---",
+		"現在の容量: %d":                   "Current capacity: %d",
+		"拡張後のslice長: %d, 容量: %d":      "Slice length after growth: %d, capacity: %d",
+		"古いデータポインタ: %p":               "Old data pointer: %p",
+		"新しいslice[0]のアドレス: %p":        "Address of the new slice[0]: %p",
+		"新しいslice[1]のアドレス: %p":        "Address of the new slice[1]: %p",
+		"✅ ポインタは有効":                   "✅ The pointer is valid",
+		"→ 古いメモリ位置を指し続けている":           "→ it keeps pointing to the old memory location",
+		"--- 6. なぜポインタが更新されないのか？ ---": "--- 6. Why isn't the pointer updated? ---",
+		"Goのsliceは以下の3つの要素で構成されています：": "A Go slice consists of the following three parts:",
+		"1. データポインタ（実際の配列の先頭アドレス）":    "1. Data pointer (address of the start of the underlying array)",
+		"2. 長さ（len）":                  "2. Length (len)",
+		"3. 容量（cap）":                  "3. Capacity (cap)",
+		"ポインタ変数（middlePtr）は、特定のメモリアドレスを保持します。": "A pointer variable (middlePtr) holds a specific memory address.",
+		"sliceが再割り当てされると：":                     "When the slice is reallocated:",
+		"- 新しいメモリ領域にデータがコピーされる":                "- the data is copied to a new memory region",
+		"- sliceのデータポインタが新しいアドレスを指す":           "- the slice's data pointer points to the new address",
+		"- しかし、古いポインタ変数は古いアドレスを保持し続ける":         "- but the old pointer variable keeps holding the old address",
+		"これが「古いポインタのまま」になってしまう理由です。":           "This is why the pointer \"stays stale\".",
+		"--- 7. 安全な方法（方法2）との比較 ---":            "--- 7. Comparison with the safe method (method 2) ---",
+		"方法2では：":                      "With method 2:",
+		"- ポインタを保持しない":                "- no pointer is held",
+		"- 毎回 slice[middleIndex] を計算": "- slice[middleIndex] is computed every time",
+		"- sliceが変更されても、常に最新の位置を参照":   "- it always refers to the latest position even if the slice changes",
+		"- メモリ再割り当ての影響を受けない":          "- it is not affected by memory reallocation",
+		"実際の比較：":                      "Actual comparison:",
+		"方法2で計算した真ん中の要素: %v":          "Middle element computed with method 2: %v",
+		"方法3のポインタが指す要素: %v":           "Element pointed to by method 3's pointer: %v",
+		"✅ 同じメモリ位置（安全）":               "✅ Same memory location (safe)",
+		"❌ 異なるメモリ位置（危険な状態）":           "❌ Different memory location (dangerous state)",
+		"方法2のアドレス: %p":                "Address with method 2: %p",
+		"方法3のアドレス: %p":                "Address with method 3: %p",
+		"--- 8. チャンク分割による解決策 ---":     "--- 8. The chunked solution ---",
+		"連続した1つの配列にこだわらなければ、再割り当ては避けられます。":                     "If you do not insist on one contiguous array, reallocation can be avoided.",
+		"ChunkedSliceは固定サイズのチャンクを追加して伸びるため、要素は移動しません。":         "ChunkedSlice grows by adding fixed-size chunks, so elements never move.",
+		"真ん中の要素のアドレス: %p, 値: %v":                               "Address of the middle element: %p, value: %v",
+		"Append/PushFront後の長さ: %d":                             "Length after Append/PushFront: %d",
+		"✅ 同じメモリ位置（要素は移動していない）":                                "✅ Same memory location (the element has not moved)",
+		"ポインタが指す値: %v":                                         "Value pointed to: %v",
+		"=== 実際の使用パターンの検証 ===":                                 "=== Verifying real-world usage patterns ===",
+		"--- パターン1: 一度だけアクセス（最も一般的）---":                        "--- Pattern 1: single access (most common) ---",
+		"ポインタアクセス: %d":                                         "Pointer access: %d",
+		"インデックスアクセス: %d":                                       "Index access: %d",
+		"--- パターン2: 複数回アクセス（sliceが変更されない場合）---":                "--- Pattern 2: repeated access (slice not modified) ---",
+		"アクセス%d回目: %d":                                         "Access #%d: %d",
+		"--- パターン3: 複数回アクセス（sliceが変更される場合）---":                 "--- Pattern 3: repeated access (slice modified) ---",
+		"sliceを変更しながらアクセス...":                                  "Accessing while modifying the slice...",
+		"変更前アクセス%d回目: %d":                                      "Access #%d before change: %d",
+		"slice長: %d":                                           "Slice length: %d",
+		"変更後アクセス%d回目: %d":                                      "Access #%d after change: %d",
+		"--- パターン4: 高頻度アクセス（パフォーマンス重視）---":                     "--- Pattern 4: high-frequency access (performance-oriented) ---",
+		"ポインタアクセス":                                             "Pointer access",
+		"インデックスアクセス":                                           "Index access",
+		"--- 結論 ---":                                           "--- Conclusion ---",
+		"✅ 一度だけアクセス: ポインタアクセスは安全で高速":                           "✅ Single access: pointer access is safe and fast",
+		"✅ 複数回アクセス（slice変更なし）: ポインタアクセスは安全で高速":                 "✅ Repeated access (no slice changes): pointer access is safe and fast",
+		"❌ 複数回アクセス（slice変更あり）: ポインタアクセスは危険":                    "❌ Repeated access (with slice changes): pointer access is dangerous",
+		"✅ 高頻度アクセス: ポインタアクセスは大幅に高速":                            "✅ High-frequency access: pointer access is much faster",
+		"実際の使用では、sliceが変更されないことが多いため、":                         "In practice the slice is often not modified,",
+		"ポインタアクセスの危険性は過大評価されている可能性があります。":                      "so the danger of pointer access may be overestimated.",
+		"=== 大きな要素と多数の要素でのパフォーマンス比較 ===":                       "=== Performance comparison with large elements and many elements ===",
+		"--- 大きな要素でのテスト ---":                                   "--- Test with large elements ---",
+		"大きな要素のslice作成完了: %d個":                                 "Created slice of large elements: %d items",
+		"--- パフォーマンス比較（1000回アクセス）---":                          "--- Performance comparison (1000 accesses) ---",
+		"--- メモリ使用量の比較 ---":                                    "--- Memory usage comparison ---",
+		"1つのLargeStructのサイズ: %d bytes (unsafe.Sizeof)":         "Size of one LargeStruct: %d bytes (unsafe.Sizeof)",
+		"1つのLargeStructの実際のサイズ: %d bytes (参照先を含む)":             "Actual size of one LargeStruct: %d bytes (including referenced memory)",
+		"slice全体の推定サイズ: %d MB":                                 "Estimated size of the whole slice: %d MB",
+		"--- 詳細分析 ---":                                         "--- Detailed analysis ---",
+		"フィールドごとの内訳:":                                          "Breakdown by field:",
+		"%-8s 本体 %5d bytes, 参照先 %5d bytes":                     "%-8s inline %5d bytes, referenced %5d bytes",
+		"アクセスパターンの分析:":                                         "Access pattern analysis:",
+		"1. 一度だけアクセス:":                                         "1. Single access:",
+		"ポインタ":                                                 "Pointer",
+		"インデックス":                                               "Index",
+		"2. 複数回アクセス（同じ要素）:":                                    "2. Repeated access (same element):",
+		"ポインタ (%d回)":                                           "Pointer (%d times)",
+		"インデックス (%d回)":                                         "Index (%d times)",
+		"3. メモリコピーの影響:":                                        "3. Impact of memory copies:",
+		"構造体サイズ: %d bytes":                                     "Struct size: %d bytes",
+		"ポインタアクセス: 8 bytes (ポインタのみ)":                           "Pointer access: 8 bytes (pointer only)",
+		"インデックスアクセス: %d bytes (構造体全体をコピー)":                     "Index access: %d bytes (copies the whole struct)",
+		"コピー量の差: %dx":                                          "Copy size ratio: %dx",
+		"4. 結論:":                                               "4. Conclusion:",
+		"✅ 大きな要素では、ポインタアクセスが大幅に高速":                             "✅ With large elements, pointer access is much faster",
+		"✅ メモリコピーのオーバーヘッドが大きい":                                 "✅ The memory copy overhead is large",
+		"✅ 要素が大きいほど、ポインタアクセスの優位性が増す":                           "✅ The larger the element, the bigger the advantage of pointer access",
+		"⚠️  このサイズでは、統計的に有意な差は見られない":                           "⚠️  At this size there is no statistically significant difference",
+		"方法1〜3で大きなsliceの真ん中のmapにアクセス（-size, -key, -data-seed）": "Access the middle map of a large slice with methods 1–3 (-size, -key, -data-seed)",
+		"方法1〜3のパフォーマンスを統計的に比較（-size, -key, -iterations, -runs, -seed, -sizes, -output, -lang, -baseline, -data-seed）": "Statistically compare the performance of methods 1–3 (-size, -key, -iterations, -runs, -seed, -sizes, -output, -lang, -baseline, -data-seed)",
+		"ポインタアクセスの危険性とハンドルによる解決策":                                                                                     "The danger of pointer access and the handle-based solution",
+		"sliceの再割り当てとポインタの関係を説明":                                                                                      "Explain slice reallocation and its effect on pointers",
+		"実際の使用パターンを検証（-size, -key, -iterations, -runs, -seed, -sizes, -output, -lang, -baseline, -data-seed）":         "Verify real-world usage patterns (-size, -key, -iterations, -runs, -seed, -sizes, -output, -lang, -baseline, -data-seed)",
+		"大きな要素でのパフォーマンス比較（-size, -iterations, -runs, -seed, -sizes, -output, -lang, -baseline, -data-seed）":           "Performance comparison with large elements (-size, -iterations, -runs, -seed, -sizes, -output, -lang, -baseline, -data-seed)",
+		"sliceのサイズ（0なら各デモのデフォルト）":                                                                                     "slice size (0 uses each demo's default)",
+		"1回の計測でアクセスする回数（0なら各デモのデフォルト）":                                                                                "number of accesses per measurement (0 uses each demo's default)",
+		"計測の回数（0ならデフォルト）":                                                                                             "number of measurements (0 uses the default)",
+		"アクセスするmapのキー":                "map key to access",
+		"計測順序のシャッフルに使うシード（0なら固定順）":    "seed for shuffling the measurement order (0 keeps a fixed order)",
+		"出力形式（text, json, csv, html）": "output format (text, json, csv, html)",
+		"表示言語（ja, en）。省略時は LANG から判定": "display language (ja, en); detected from LANG when omitted",
+		"フラグ:": "Flags:",
+		"エラー: 不明な言語 %q（ja または en）":                                   "Error: unknown language %q (ja or en)",
+		"不明なサブコマンド: %s":                                              "Unknown subcommand: %s",
+		"使い方: slice_practice [サブコマンド] [フラグ]":                         "Usage: slice_practice [subcommand] [flags]",
+		"サブコマンド:":                                                    "Subcommands:",
+		"すべてのデモを順に実行（省略時）":                                           "Run all demos in order (default)",
+		"構造体のフィールド配置を表示（layout [-goarch=arch] <pkg>.<Type>）":         "Show the field layout of a struct (layout [-goarch=arch] <pkg>.<Type>)",
+		"対象のGOARCH（省略時はホスト）":                                         "target GOARCH (host when omitted)",
+		"使い方: slice_practice layout [-goarch=arch] <pkg>.<Type>":     "Usage: slice_practice layout [-goarch=arch] <pkg>.<Type>",
+		"現在のフィールド順:":                                                 "Current field order:",
+		"パディングが最小になるフィールド順（%d bytes 削減）:":                            "Field order with minimal padding (saves %d bytes):",
+		"✅ 現在のフィールド順でパディングは最小です":                                     "✅ The current field order already has minimal padding",
+		"比較するベースラインファイル（空なら比較しない）":                                   "baseline file to compare against (no comparison when empty)",
+		"今回の計測結果を -baseline のファイルに保存する":                              "save this run's results to the -baseline file",
+		"リグレッションとみなす変化率（%）":                                          "slowdown (%) treated as a regression",
+		"エラー: -save-baseline には -baseline が必要です":                     "Error: -save-baseline requires -baseline",
+		"エラー: %s は計測結果を記録しないため -output=%s は使えません（text のみ）":           "Error: %s records no measurements, so -output=%s is not available (text only)",
+		"ベースラインを保存しました: %s (%s, %d件)":                                "Saved baseline: %s (%s, %d records)",
+		"%s のベースラインがありません（-save-baseline で保存できます）":                   "No baseline for %s (save one with -save-baseline)",
+		"=== ベースラインとの比較（%s → %s, しきい値 +%.1f%%）===":                   "=== Comparison with baseline (%s → %s, threshold +%.1f%%) ===",
+		"ℹ️  Goのバージョンが異なるベースラインと比較しています（%s → %s）。":                   "ℹ️  Comparing with a baseline from a different Go version (%s → %s).",
+		"以下の差分はGoのバージョンアップによるものとして扱い、失敗にはしません。":                      "The diffs below are attributed to the Go upgrade and do not fail the run.",
+		"%s: ベースラインにありません":                                           "%s: not in the baseline",
+		"%s: 今回は計測していません":                                            "%s: not measured in this run",
+		"✅ リグレッションはありません":                                            "✅ No regressions",
+		"⚠️  %d件がしきい値を超えて遅くなりました（Goのバージョンの違いによる差分）":                  "⚠️  %d results slowed down beyond the threshold (explained by the Go version change)",
+		"❌ %d件がしきい値を超えて遅くなりました":                                      "❌ %d results slowed down beyond the threshold",
+		"カンマ区切りのsliceのサイズ。サイズごとに繰り返し計測する（-size より優先）":                "comma-separated slice sizes; measurements are repeated for each size (overrides -size)",
+		"slice_practice 計測レポート":                                      "slice_practice measurement report",
+		"割り当てとGC":                                                    "Allocations and GC",
+		"GC停止":                                                       "GC pause",
+		"基準との比":                                                      "vs. baseline",
+		"sliceの長さと時間 (ns/op)":                                        "Time against slice length (ns/op)",
+		"sliceの長さ（対数目盛）":                                             "slice length (log scale)",
+		"sliceの長さ":                                                   "slice length",
+		"--- 9. 再割り当ての記録 ---":                                        "--- 9. Recording reallocations ---",
+		"手順4と5の append を GrowthTracer 経由でやり直し、再割り当てをすべて記録します。":       "Redo the appends of steps 4 and 5 through GrowthTracer and record every reallocation.",
+		"再割り当て: 容量 %d → %d (x%.2f), データポインタ %#x → %#x, コピー %d bytes": "Reallocation: cap %d → %d (x%.2f), data pointer %#x → %#x, copied %d bytes",
+		"再割り当ての回数: %d":                                               "Number of reallocations: %d",
+		"容量に空きがある間は append しても再割り当ては起きず、既存のポインタも有効なままです。": "While there is spare capacity, append does not reallocate and existing pointers stay valid.",
+		"任意の要素サイズの表は growth サブコマンドで確認できます。":               "Use the growth subcommand to see the table for any element size.",
+		"要素のサイズ（bytes）。省略時は LargeStruct のサイズ":             "element size in bytes (size of LargeStruct when omitted)",
+		"1要素ずつ append する回数": "number of single-element appends",
+		"要素サイズごとのsliceの容量の伸び方を表示・予測（growth [-elemsize=bytes] [-n=count] [-cap=n] [-go=version]）":     "Show or predict how slice capacity grows for an element size (growth [-elemsize=bytes] [-n=count] [-cap=n] [-go=version])",
+		"使い方: slice_practice growth [-elemsize=bytes] [-n=count] [-cap=n] [-pointers] [-go=version]": "Usage: slice_practice growth [-elemsize=bytes] [-n=count] [-cap=n] [-pointers] [-go=version]",
+		"make で確保しておく容量（予測のみ）":                                                                       "capacity preallocated with make (prediction only)",
+		"予測に使うGoのバージョン（go1.17 など）":                                                                   "Go version to predict for (e.g. go1.17)",
+		"make(0, %d) の要素サイズ %d bytes（ポインタ: %v）のsliceに1要素ずつ append したときの予測（%s）:":                      "Predicted growth when appending one element at a time to make(0, %d) of %d-byte elements (pointers: %v, %s):",
+		"中断しました": "Interrupted",
+		"データ生成の乱数のシード（0なら i*10 などの算術的なデータ）":                                        "seed for generating random data (0 uses arithmetic data such as i*10)",
+		"生成するmapのカンマ区切りのキー（-data-seed 用、省略時は key1,key2,key3）":                      "comma-separated keys of the generated maps (for -data-seed; default key1,key2,key3)",
+		"生成する値の分布（-data-seed 用、uniform[:lo,hi], normal[:mean,stddev], exp[:mean]）": "distribution of the generated values (for -data-seed; uniform[:lo,hi], normal[:mean,stddev], exp[:mean])",
+		"要素のmapを nil にする確率（-data-seed 用）":                                          "probability that an element's map is nil (for -data-seed)",
+		"mapからキーを欠けさせる確率（-data-seed 用）":                                            "probability that a key is missing from a map (for -data-seed)",
+		"エラー: -keys, -dist, -nil-prob, -missing-prob には -data-seed が必要です":          "Error: -keys, -dist, -nil-prob and -missing-prob require -data-seed",
+		"❌ ベースラインと共通の計測がありません（同じサブコマンドで -save-baseline したか確認してください）":               "❌ No measurements in common with the baseline (check that it was saved with -save-baseline for the same subcommand)",
+		"要素がポインタを含む（-elemsize 指定時。省略時の LargeStruct は含む）":                           "elements contain pointers (with -elemsize; the default LargeStruct does)",
+		"%s（%d bytes、ポインタ: %v）のsliceに1要素ずつ append したときの容量の伸び方:":                    "Capacity growth when appending one element at a time to a slice of %s (%d bytes, pointers: %v):",
+	})
+}
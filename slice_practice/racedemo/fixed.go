@@ -0,0 +1,82 @@
+package racedemo
+
+import (
+	"sync"
+
+	"slice_practice/sliceaccess"
+)
+
+// fixedAppend は racyAppend の修正版です。
+// ポインタを保持せず、ConcurrentSliceOfMaps の Middle で毎回真ん中の要素を引き直します（方法2）。
+// Append はsliceへの書き込みロックを取るため、読み込みと競合しません。
+func fixedAppend(iterations int) {
+	c := sliceaccess.NewConcurrentSliceOfMaps(newSlice(3), 0)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		sum := 0
+		for range iterations {
+			value, _ := c.Middle("value")
+			sum += value
+		}
+		resultSink = sum
+	}()
+	go func() {
+		defer wg.Done()
+		for i := range iterations {
+			c.Append(map[string]int{"id": 3 + i})
+		}
+	}()
+	wg.Wait()
+}
+
+// fixedPrepend は racyPrepend の修正版です。
+// 先頭への挿入はすべてのインデックスをずらすため、要素ごとのロックでは守れません。
+// 1つの sync.Mutex で slice 全体を守り、ロックを取るたびに真ん中のインデックスを計算し直します。
+func fixedPrepend(iterations int) {
+	slice := newSlice(3)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range iterations {
+			mu.Lock()
+			slice[len(slice)/2] = map[string]int{"id": -1, "value": i}
+			mu.Unlock()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := range iterations {
+			mu.Lock()
+			slice = append([]map[string]int{{"id": -2 - i}}, slice...)
+			mu.Unlock()
+		}
+	}()
+	wg.Wait()
+}
+
+// fixedStalePointerWrite は racyStalePointerWrite の修正版です。
+// ポインタの代わりにインデックスを保持し、ConcurrentSliceOfMaps の Set で書き込むため、
+// 再割り当ての後も書き込みは現在の要素に反映されます。
+func fixedStalePointerWrite(iterations int) {
+	c := sliceaccess.NewConcurrentSliceOfMaps(newSlice(3), 0)
+	const middleIndex = 1
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range iterations {
+			c.Set(middleIndex, "value", i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := range iterations {
+			c.Append(map[string]int{"id": 3 + i})
+		}
+	}()
+	wg.Wait()
+}
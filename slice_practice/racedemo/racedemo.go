@@ -0,0 +1,35 @@
+// Package racedemo は、demonstratePointerDanger の並行版です。
+// 1つのgoroutineが middlePtr := &slice[i] を保持して使い続け、別のgoroutineが
+// sliceに append（または先頭に挿入）するシナリオを、データ競合のある版と修正版の組で提供します。
+//
+// データ競合のある版は go test -race -tags racedemo ./racedemo で検出されて失敗し、
+// 修正版は go test -race ./racedemo で成功します。
+package racedemo
+
+// Scenario は1つの並行アクセスのシナリオです
+type Scenario struct {
+	Name string
+	// Racy はデータ競合のある実装です。-race で実行すると検出されます。
+	Racy func(iterations int)
+	// Fixed は同じ処理を競合なしで行う実装です
+	Fixed func(iterations int)
+}
+
+// Scenarios はすべてのシナリオです
+var Scenarios = []Scenario{
+	{"append", racyAppend, fixedAppend},
+	{"prepend", racyPrepend, fixedPrepend},
+	{"stale-pointer-write", racyStalePointerWrite, fixedStalePointerWrite},
+}
+
+// newSlice は id と value を持つmapのsliceを作成します（createLargeSlice の小さな版）
+func newSlice(n int) []map[string]int {
+	slice := make([]map[string]int, n)
+	for i := range slice {
+		slice[i] = map[string]int{"id": i, "value": i * 10}
+	}
+	return slice
+}
+
+// resultSink は読んだ値の書き込み先で、コンパイラに読み込みを消されないようにします
+var resultSink int
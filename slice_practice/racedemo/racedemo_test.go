@@ -0,0 +1,12 @@
+package racedemo
+
+import "testing"
+
+// 修正版はすべて -race で成功する
+func TestFixed(t *testing.T) {
+	for _, s := range Scenarios {
+		t.Run(s.Name, func(t *testing.T) {
+			s.Fixed(1000)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package racedemo
+
+import "sync"
+
+// racyAppend は、一方のgoroutineが毎回 &slice[len/2] を取って読み、
+// もう一方が同じ slice 変数に append します。
+// slice 変数（データポインタ・長さ・容量）の読み書きが同期されていないため競合します。
+func racyAppend(iterations int) {
+	slice := newSlice(3)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		sum := 0
+		for range iterations {
+			middlePtr := &slice[len(slice)/2] // slice を同期なしで読む
+			sum += (*middlePtr)["value"]
+		}
+		resultSink = sum
+	}()
+	go func() {
+		defer wg.Done()
+		for i := range iterations {
+			slice = append(slice, map[string]int{"id": 3 + i}) // slice を同期なしで書く
+		}
+	}()
+	wg.Wait()
+}
+
+// racyPrepend は、一方のgoroutineが middlePtr を通して真ん中の要素を書き換え、
+// もう一方が先頭に要素を挿入します。挿入は全要素を新しい配列へコピーするため、
+// 要素の書き込みとコピーのための読み込みも競合します。
+func racyPrepend(iterations int) {
+	slice := newSlice(3)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range iterations {
+			middlePtr := &slice[len(slice)/2]
+			*middlePtr = map[string]int{"id": -1, "value": i}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := range iterations {
+			slice = append([]map[string]int{{"id": -2 - i}}, slice...)
+		}
+	}()
+	wg.Wait()
+}
+
+// racyStalePointerWrite は、開始前に取った middlePtr を保持したまま要素を書き換え続けます。
+// もう一方のgoroutineの append が再割り当てのために古い配列を読むため競合します。
+// 再割り当ての後の書き込みは古い配列に対して行われ、slice からは見えなくなります。
+func racyStalePointerWrite(iterations int) {
+	slice := newSlice(3)
+	middlePtr := &slice[1]
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range iterations {
+			*middlePtr = map[string]int{"id": 1, "value": i}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		s := slice
+		for i := range iterations {
+			s = append(s, map[string]int{"id": 3 + i})
+		}
+		resultSink = len(s)
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,21 @@
+//go:build racedemo
+
+package racedemo
+
+import "testing"
+
+// データ競合のある版です。-race で実行すると、どのシナリオも
+// "race detected during execution of test" で失敗します。
+//
+//	go test -race -tags racedemo ./racedemo
+//
+// 1つのシナリオだけを確認するには -run で絞り込みます。
+//
+//	go test -race -tags racedemo -run 'TestRacy/append$' ./racedemo
+func TestRacy(t *testing.T) {
+	for _, s := range Scenarios {
+		t.Run(s.Name, func(t *testing.T) {
+			s.Racy(1000)
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// palette はグラフの系列の色です（アクセス方法ごとに順に割り当てます）
+var palette = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#9c755f"}
+
+// group は同じ比較のまとまり（コマンドとシナリオ）のレコードです
+type group struct {
+	Command  string
+	Scenario string
+	Records  []Record
+}
+
+// groupRecords はレコードをコマンドとシナリオごとに、最初に現れた順でまとめます
+func groupRecords(records []Record) []*group {
+	var groups []*group
+	index := map[[2]string]*group{}
+	for _, r := range records {
+		k := [2]string{r.Command, r.Scenario}
+		g, ok := index[k]
+		if !ok {
+			g = &group{Command: r.Command, Scenario: r.Scenario}
+			index[k] = g
+			groups = append(groups, g)
+		}
+		g.Records = append(g.Records, r)
+	}
+	return groups
+}
+
+// sliceLens は g に含まれるsliceの長さを昇順で返します
+func (g *group) sliceLens() []int {
+	var lens []int
+	for _, r := range g.Records {
+		if !slices.Contains(lens, r.SliceLen) {
+			lens = append(lens, r.SliceLen)
+		}
+	}
+	slices.Sort(lens)
+	return lens
+}
+
+// strategies は g に含まれるアクセス方法を最初に現れた順で返します
+func (g *group) strategies() []string {
+	var names []string
+	for _, r := range g.Records {
+		if !slices.Contains(names, r.Strategy) {
+			names = append(names, r.Strategy)
+		}
+	}
+	return names
+}
+
+// WriteHTML はレコードを、グラフと表を含む1つのHTMLファイルとして w に書き出します。
+// グラフはSVGとしてこの場で描画するため、外部のスクリプトやCDNは不要です。
+// t は見出しなどの翻訳に使う関数で、nil なら日本語のまま書き出します。
+func WriteHTML(w io.Writer, records []Record, t func(string) string) error {
+	if t == nil {
+		t = func(s string) string { return s }
+	}
+	type section struct {
+		Title string
+		Bars  []template.HTML // sliceの長さごとの棒グラフ
+		Line  template.HTML   // sliceの長さに対する時間の折れ線グラフ（長さが1種類なら空）
+	}
+	var sections []section
+	for _, g := range groupRecords(records) {
+		s := section{Title: g.Command + " / " + g.Scenario}
+		lens := g.sliceLens()
+		for _, n := range lens {
+			var recs []Record
+			for _, r := range g.Records {
+				if r.SliceLen == n {
+					recs = append(recs, r)
+				}
+			}
+			s.Bars = append(s.Bars, barChart(fmt.Sprintf("%s (len=%d)", recs[0].ElementType, n), recs, t))
+		}
+		if len(lens) > 1 {
+			s.Line = lineChart(g, t)
+		}
+		sections = append(sections, s)
+	}
+
+	return htmlTemplate.Execute(w, map[string]any{
+		"T":        t,
+		"Sections": sections,
+		"Records":  records,
+	})
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"ns":       func(f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) },
+	"duration": func(d time.Duration) string { return d.String() },
+	"ratio": func(r Record) string {
+		if r.Baseline == "" || r.Strategy == r.Baseline {
+			return ""
+		}
+		return fmt.Sprintf("%.2fx (p=%.4f)", r.Ratio, r.PValue)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{call .T "slice_practice 計測レポート"}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; color: #222; }
+h2 { border-bottom: 1px solid #ccc; padding-bottom: .2em; }
+.charts { display: flex; flex-wrap: wrap; gap: 1em; }
+table { border-collapse: collapse; font-size: 90%; }
+th, td { border: 1px solid #ccc; padding: .3em .6em; }
+td.num { text-align: right; font-variant-numeric: tabular-nums; }
+tr.significant td { background: #eef7ee; }
+</style>
+</head>
+<body>
+<h1>{{call .T "slice_practice 計測レポート"}}</h1>
+{{range .Sections}}
+<h2>{{.Title}}</h2>
+<div class="charts">
+{{range .Bars}}{{.}}
+{{end}}{{if .Line}}{{.Line}}
+{{end}}</div>
+{{end}}
+<h2>{{call .T "割り当てとGC"}}</h2>
+<table>
+<tr><th>command</th><th>scenario</th><th>strategy</th><th>element_type</th><th>slice_len</th><th>ns/op</th><th>B/op</th><th>allocs/op</th><th>GC</th><th>{{call .T "GC停止"}}</th><th>{{call .T "基準との比"}}</th></tr>
+{{range .Records}}<tr{{if .Significant}} class="significant"{{end}}><td>{{.Command}}</td><td>{{.Scenario}}</td><td>{{call $.T .Strategy}}</td><td>{{.ElementType}}</td><td class="num">{{.SliceLen}}</td><td class="num">{{ns .NsPerOp}}</td><td class="num">{{ns .BytesPerOp}}</td><td class="num">{{ns .AllocsPerOp}}</td><td class="num">{{.GCCycles}}</td><td class="num">{{duration .GCPause}}</td><td class="num">{{ratio .}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// barChart は recs の ns/op をアクセス方法ごとの横棒グラフとして描画します。
+// 標準偏差をひげとして重ねます。
+func barChart(title string, recs []Record, t func(string) string) template.HTML {
+	const (
+		labelWidth = 200
+		plotWidth  = 320
+		rowHeight  = 28
+		top        = 30
+	)
+	width := labelWidth + plotWidth + 110
+	height := top + rowHeight*len(recs) + 10
+
+	var max float64
+	for _, r := range recs {
+		max = math.Max(max, r.NsPerOp+r.StdDevNs)
+	}
+	if max == 0 {
+		max = 1
+	}
+	scale := plotWidth / max
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-size="12">`, width, height)
+	fmt.Fprintf(&b, `<text x="0" y="16" font-weight="bold">%s</text>`, template.HTMLEscapeString(title))
+	for i, r := range recs {
+		y := top + i*rowHeight
+		w := r.NsPerOp * scale
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%s</text>`, labelWidth-6, y+15, template.HTMLEscapeString(t(r.Strategy)))
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%.1f" height="%d" fill="%s"/>`, labelWidth, y+4, w, rowHeight-8, palette[i%len(palette)])
+		if r.StdDevNs > 0 {
+			lo, hi := math.Max(0, r.NsPerOp-r.StdDevNs)*scale, (r.NsPerOp+r.StdDevNs)*scale
+			fmt.Fprintf(&b, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="#333"/>`, labelWidth+lo, y+rowHeight/2, labelWidth+hi, y+rowHeight/2)
+		}
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d">%.2f ns/op</text>`, labelWidth+math.Max(w, (r.NsPerOp+r.StdDevNs)*scale)+6, y+15, r.NsPerOp)
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// lineChart は g の ns/op をsliceの長さに対する折れ線グラフとして描画します。
+// 長さが10倍以上に広がっている場合は横軸を対数目盛にします。
+func lineChart(g *group, t func(string) string) template.HTML {
+	const (
+		width, height = 520, 300
+		left, right   = 70, 20
+		top, bottom   = 30, 40
+	)
+	lens := g.sliceLens()
+	names := g.strategies()
+
+	var maxNs float64
+	for _, r := range g.Records {
+		maxNs = math.Max(maxNs, r.NsPerOp)
+	}
+	if maxNs == 0 {
+		maxNs = 1
+	}
+	maxNs *= 1.1
+
+	logX := lens[0] > 0 && lens[len(lens)-1] >= 10*lens[0]
+	xv := func(n int) float64 {
+		if logX {
+			return math.Log10(float64(n))
+		}
+		return float64(n)
+	}
+	x0, x1 := xv(lens[0]), xv(lens[len(lens)-1])
+	plotW, plotH := float64(width-left-right), float64(height-top-bottom)
+	px := func(n int) float64 { return float64(left) + (xv(n)-x0)/(x1-x0)*plotW }
+	py := func(ns float64) float64 { return float64(top) + plotH - ns/maxNs*plotH }
+
+	legendHeight := 18 * len(names)
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-size="12">`, width, height+legendHeight)
+	fmt.Fprintf(&b, `<text x="0" y="16" font-weight="bold">%s</text>`, template.HTMLEscapeString(t("sliceの長さと時間 (ns/op)")))
+
+	// 軸と目盛り
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#333"/>`, left, top, left, height-bottom)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#333"/>`, left, height-bottom, width-right, height-bottom)
+	for i := 0; i <= 4; i++ {
+		ns := maxNs * float64(i) / 4
+		y := py(ns)
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#eee"/>`, left+1, y, width-right, y)
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end">%.1f</text>`, left-4, y+4, ns)
+	}
+	for _, n := range lens {
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">%d</text>`, px(n), height-bottom+16, n)
+	}
+	if logX {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%s</text>`, width-right, height-4, template.HTMLEscapeString(t("sliceの長さ（対数目盛）")))
+	} else {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%s</text>`, width-right, height-4, template.HTMLEscapeString(t("sliceの長さ")))
+	}
+
+	// アクセス方法ごとの系列と凡例
+	for i, name := range names {
+		color := palette[i%len(palette)]
+		var series []Record
+		for _, r := range g.Records {
+			if r.Strategy == name {
+				series = append(series, r)
+			}
+		}
+		slices.SortStableFunc(series, func(a, b Record) int { return a.SliceLen - b.SliceLen })
+		var points []string
+		for _, r := range series {
+			x, y := px(r.SliceLen), py(r.NsPerOp)
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+			fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s: %.2f ns/op</title></circle>`, x, y, color, template.HTMLEscapeString(t(name)), r.NsPerOp)
+		}
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(points, " "), color)
+		ly := height + 18*i + 4
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="12" height="12" fill="%s"/>`, left, ly, color)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`, left+18, ly+11, template.HTMLEscapeString(t(name)))
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
@@ -0,0 +1,155 @@
+// Package report は、計測結果をグラフ化や差分比較に使える構造化レコードとして書き出します。
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"slice_practice/measure"
+)
+
+// Record は1つのアクセス方法（または計測区間）の計測結果です
+type Record struct {
+	Command     string        `json:"command"`      // サブコマンド（bench, large など）
+	Scenario    string        `json:"scenario"`     // 同じコマンド内の比較のまとまり
+	Strategy    string        `json:"strategy"`     // アクセス方法
+	ElementType string        `json:"element_type"` // sliceの要素の型
+	SliceLen    int           `json:"slice_len"`
+	Iterations  int           `json:"iterations"` // 1回の計測でのアクセス回数
+	Runs        int           `json:"runs"`       // 計測の回数（-runs の値）
+	Outliers    int           `json:"outliers"`   // 統計量の計算から除いた外れ値の数
+	NsPerOp     float64       `json:"ns_per_op"`  // 1回あたりの時間（中央値）
+	MeanNs      float64       `json:"mean_ns"`
+	StdDevNs    float64       `json:"stddev_ns"`
+	BytesPerOp  float64       `json:"bytes_per_op"`
+	AllocsPerOp float64       `json:"allocs_per_op"`
+	GCCycles    uint32        `json:"gc_cycles"`
+	GCPause     time.Duration `json:"gc_pause_ns"`
+	Baseline    string        `json:"baseline,omitempty"` // 比率の基準にしたアクセス方法
+	Ratio       float64       `json:"ratio,omitempty"`    // 基準の時間 / この方法の時間（1より大きければ高速）
+	PValue      float64       `json:"p_value,omitempty"`  // 基準との差のMann-WhitneyのU検定のp値
+	Significant bool          `json:"significant"`
+}
+
+// FromResult は measure.Result からレコードを作成します。
+// baseline が nil でなければ、基準との比率と検定結果を記録します。
+func FromResult(r measure.Result, baseline *measure.Result) Record {
+	rec := Record{
+		Strategy:    r.Name,
+		Iterations:  r.Iterations,
+		Runs:        r.N + r.Outliers,
+		Outliers:    r.Outliers,
+		NsPerOp:     r.Median,
+		MeanNs:      r.Mean,
+		StdDevNs:    r.StdDev,
+		BytesPerOp:  r.Mem.BytesPerOp(),
+		AllocsPerOp: r.Mem.AllocsPerOp(),
+		GCCycles:    r.Mem.GCCycles,
+		GCPause:     r.Mem.GCPause,
+	}
+	if baseline != nil {
+		c := measure.Compare(*baseline, r, measure.DefaultConfig.Alpha)
+		rec.Baseline = baseline.Name
+		rec.Ratio = c.Speedup
+		rec.PValue = c.P
+		rec.Significant = c.Significant
+	}
+	return rec
+}
+
+// FromSection は measure.Section で計測した区間からレコードを作成します
+func FromSection(name string, d time.Duration, m measure.MemStats) Record {
+	return Record{
+		Strategy:    name,
+		Iterations:  1,
+		Runs:        1,
+		NsPerOp:     float64(d),
+		MeanNs:      float64(d),
+		BytesPerOp:  m.BytesPerOp(),
+		AllocsPerOp: m.AllocsPerOp(),
+		GCCycles:    m.GCCycles,
+		GCPause:     m.GCPause,
+	}
+}
+
+// Format は出力形式です
+type Format string
+
+const (
+	Text Format = "text"
+	JSON Format = "json"
+	CSV  Format = "csv"
+	HTML Format = "html" // グラフ付きのレポート（WriteHTML）
+)
+
+// ParseFormat は -output フラグの値を解釈します
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case Text, JSON, CSV, HTML:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown output format %q (want text, json, csv or html)", s)
+}
+
+// Write はレコードを format で w に書き出します。Text の場合は何も書きません。
+func Write(w io.Writer, format Format, records []Record) error {
+	switch format {
+	case JSON:
+		if records == nil {
+			records = []Record{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case CSV:
+		return writeCSV(w, records)
+	case HTML:
+		return WriteHTML(w, records, nil)
+	}
+	return nil
+}
+
+// csvHeader はCSVの列名です（Record の JSON のキーと同じ）
+var csvHeader = []string{
+	"command", "scenario", "strategy", "element_type", "slice_len", "iterations", "runs", "outliers",
+	"ns_per_op", "mean_ns", "stddev_ns", "bytes_per_op", "allocs_per_op", "gc_cycles", "gc_pause_ns",
+	"baseline", "ratio", "p_value", "significant",
+}
+
+func writeCSV(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+	cw.Write(csvHeader)
+	for _, r := range records {
+		cw.Write([]string{
+			r.Command,
+			r.Scenario,
+			r.Strategy,
+			r.ElementType,
+			strconv.Itoa(r.SliceLen),
+			strconv.Itoa(r.Iterations),
+			strconv.Itoa(r.Runs),
+			strconv.Itoa(r.Outliers),
+			formatFloat(r.NsPerOp),
+			formatFloat(r.MeanNs),
+			formatFloat(r.StdDevNs),
+			formatFloat(r.BytesPerOp),
+			formatFloat(r.AllocsPerOp),
+			strconv.FormatUint(uint64(r.GCCycles), 10),
+			strconv.FormatInt(int64(r.GCPause), 10),
+			r.Baseline,
+			formatFloat(r.Ratio),
+			formatFloat(r.PValue),
+			strconv.FormatBool(r.Significant),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
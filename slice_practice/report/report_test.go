@@ -0,0 +1,90 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"slice_practice/measure"
+)
+
+var testRecords = []Record{
+	{Command: "bench", Strategy: "safe", ElementType: "map[string]int", SliceLen: 1000, NsPerOp: 20.5, Baseline: "safe", Ratio: 1},
+	{Command: "bench", Strategy: "pointer", ElementType: "map[string]int", SliceLen: 1000, NsPerOp: 10.25, Baseline: "safe", Ratio: 2, Significant: true},
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, JSON, testRecords); err != nil {
+		t.Fatal(err)
+	}
+	var got []Record
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[1] != testRecords[1] {
+		t.Errorf("round trip = %+v, want %+v", got, testRecords)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, CSV, testRecords); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || len(rows[0]) != len(csvHeader) {
+		t.Fatalf("got %d rows with %d columns", len(rows), len(rows[0]))
+	}
+	if rows[2][2] != "pointer" || rows[2][8] != "10.25" || rows[2][16] != "2" {
+		t.Errorf("row = %v", rows[2])
+	}
+}
+
+// runs は外れ値を除く前の計測回数で、除いた数は outliers に出す
+func TestFromResultRuns(t *testing.T) {
+	samples := []float64{10, 11, 9, 10, 1000}
+	r := measure.Result{Name: "safe", Iterations: 100, Summary: measure.Summarize(samples)}
+	rec := FromResult(r, nil)
+	if rec.Runs != len(samples) || rec.Outliers != 1 {
+		t.Errorf("Runs = %d, Outliers = %d; want %d, 1", rec.Runs, rec.Outliers, len(samples))
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Text, testRecords); err != nil || buf.Len() != 0 {
+		t.Errorf("Write(Text) wrote %q, err %v; want nothing", buf.String(), err)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	records := append([]Record{}, testRecords...)
+	records = append(records,
+		Record{Command: "bench", Strategy: "safe", ElementType: "map[string]int", SliceLen: 100000, NsPerOp: 30},
+		Record{Command: "bench", Strategy: "<pointer>", ElementType: "map[string]int", SliceLen: 100000, NsPerOp: 12},
+	)
+	var buf bytes.Buffer
+	if err := Write(&buf, HTML, records); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+	// sliceの長さごとの棒グラフ2つと、長さに対する折れ線グラフ1つ
+	if n := strings.Count(html, "<svg"); n != 3 {
+		t.Errorf("got %d charts, want 3", n)
+	}
+	if !strings.Contains(html, "<polyline") {
+		t.Error("no line chart for multiple slice lengths")
+	}
+	if strings.Contains(html, "<script") || strings.Contains(html, "<pointer>") {
+		t.Error("report contains a script or unescaped strategy name")
+	}
+	if !strings.Contains(html, "&lt;pointer&gt;") {
+		t.Error("escaped strategy name is missing")
+	}
+}
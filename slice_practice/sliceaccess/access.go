@@ -0,0 +1,60 @@
+// Package sliceaccess は、sliceの真ん中の要素へ安全かつ効率的にアクセスするための
+// ジェネリックなヘルパーを提供します。
+package sliceaccess
+
+// middleIndex は真ん中のインデックスを計算します
+func MiddleIndex(n int) int {
+	return n / 2
+}
+
+// Middle はsliceの真ん中の要素を値として返します。
+// 毎回 slice[len/2] を計算するため、sliceが変更されても常に最新の要素を参照します。
+func Middle[T any](slice []T) (T, error) {
+	var zero T
+	// 空のsliceチェック
+	if len(slice) == 0 {
+		return zero, ErrEmptySlice
+	}
+	return slice[MiddleIndex(len(slice))], nil
+}
+
+// MiddleRef はsliceの真ん中の要素へのポインタを返します。
+// 大きな要素のコピーを避けられますが、appendなどでsliceが再割り当てされると
+// 返したポインタは古いメモリ位置を指し続けるため注意が必要です。
+func MiddleRef[T any](slice []T) (*T, error) {
+	// 空のsliceチェック
+	if len(slice) == 0 {
+		return nil, ErrEmptySlice
+	}
+	return TrackRef(slice, MiddleIndex(len(slice))), nil
+}
+
+// MiddleLookup はsliceの真ん中のmapからkeyに対応する値を取得します。
+func MiddleLookup[K comparable, V any](slice []map[K]V, key K) (V, error) {
+	// 空のsliceチェック
+	if len(slice) == 0 {
+		var zero V
+		return zero, ErrEmptySlice
+	}
+	index := MiddleIndex(len(slice))
+	return Lookup(slice[index], key, index)
+}
+
+// Lookup はsliceの index 番目の要素である m からkeyに対応する値を取得します。
+// m が nil なら ErrNilMap、keyがなければ Index に index を入れた *KeyNotFoundError を返します。
+// Middle や MiddleRef で取り出したmapを、MiddleLookup と同じエラーで検索するときに使います。
+func Lookup[K comparable, V any](m map[K]V, key K, index int) (V, error) {
+	var zero V
+	// mapの存在チェック
+	if m == nil {
+		return zero, ErrNilMap
+	}
+
+	// キーの存在チェック
+	value, exists := m[key]
+	if !exists {
+		return zero, &KeyNotFoundError{Key: key, Index: index}
+	}
+
+	return value, nil
+}
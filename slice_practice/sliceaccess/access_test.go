@@ -0,0 +1,119 @@
+package sliceaccess
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMiddle(t *testing.T) {
+	tests := []struct {
+		name    string
+		slice   []int
+		want    int
+		wantErr error
+	}{
+		{"odd", []int{1, 2, 3}, 2, nil},
+		{"even", []int{1, 2, 3, 4}, 3, nil},
+		{"single", []int{7}, 7, nil},
+		{"empty", []int{}, 0, ErrEmptySlice},
+		{"nil", nil, 0, ErrEmptySlice},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Middle(tt.slice)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Middle(%v) = %d, %v; want %d, %v", tt.slice, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMiddleRef(t *testing.T) {
+	tests := []struct {
+		name      string
+		slice     []int
+		wantIndex int
+		wantErr   error
+	}{
+		{"odd", []int{1, 2, 3}, 1, nil},
+		{"even", []int{1, 2, 3, 4}, 2, nil},
+		{"single", []int{7}, 0, nil},
+		{"empty", []int{}, -1, ErrEmptySlice},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MiddleRef(tt.slice)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MiddleRef(%v) error = %v; want %v", tt.slice, err, tt.wantErr)
+			}
+			if tt.wantIndex < 0 {
+				if got != nil {
+					t.Errorf("MiddleRef(%v) = %p; want nil", tt.slice, got)
+				}
+				return
+			}
+			// 値のコピーではなく slice の要素そのものを指す
+			if got != &tt.slice[tt.wantIndex] {
+				t.Errorf("MiddleRef(%v) = %p; want &slice[%d]", tt.slice, got, tt.wantIndex)
+			}
+		})
+	}
+}
+
+func TestMiddleLookup(t *testing.T) {
+	m := func(v int) map[string]int { return map[string]int{"key": v} }
+	tests := []struct {
+		name      string
+		slice     []map[string]int
+		key       string
+		want      int
+		wantErr   error
+		wantIndex int // KeyNotFoundError の Index（-1 なら KeyNotFoundError を期待しない）
+	}{
+		{"odd", []map[string]int{m(1), m(2), m(3)}, "key", 2, nil, -1},
+		{"even", []map[string]int{m(1), m(2), m(3), m(4)}, "key", 3, nil, -1},
+		{"single", []map[string]int{m(7)}, "key", 7, nil, -1},
+		{"empty", nil, "key", 0, ErrEmptySlice, -1},
+		{"nil map", []map[string]int{m(1), nil, m(3)}, "key", 0, ErrNilMap, -1},
+		{"missing key", []map[string]int{m(1), m(2), m(3), m(4)}, "other", 0, nil, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MiddleLookup(tt.slice, tt.key)
+			if got != tt.want {
+				t.Errorf("MiddleLookup = %d; want %d", got, tt.want)
+			}
+			if tt.wantIndex >= 0 {
+				var notFound *KeyNotFoundError
+				if !errors.As(err, &notFound) || notFound.Key != tt.key || notFound.Index != tt.wantIndex {
+					t.Errorf("error = %v; want KeyNotFoundError{%q, %d}", err, tt.key, tt.wantIndex)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v; want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMiddleIndex(t *testing.T) {
+	for n, want := range map[int]int{1: 0, 2: 1, 3: 1, 4: 2, 1001: 500} {
+		if got := MiddleIndex(n); got != want {
+			t.Errorf("MiddleIndex(%d) = %d; want %d", n, got, want)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if got, err := Lookup(map[string]int{"a": 1}, "a", 3); got != 1 || err != nil {
+		t.Errorf("Lookup(a) = %d, %v; want 1, nil", got, err)
+	}
+	if _, err := Lookup[string, int](nil, "a", 3); !errors.Is(err, ErrNilMap) {
+		t.Errorf("Lookup(nil map) error = %v; want ErrNilMap", err)
+	}
+	var notFound *KeyNotFoundError
+	if _, err := Lookup(map[string]int{}, "b", 3); !errors.As(err, &notFound) || notFound.Key != "b" || notFound.Index != 3 {
+		t.Errorf("Lookup(missing) error = %v; want KeyNotFoundError{b, 3}", err)
+	}
+}
@@ -0,0 +1,107 @@
+package sliceaccess
+
+import "iter"
+
+// DefaultChunkSize は NewChunkedSlice に0以下を渡したときのチャンクの要素数です
+const DefaultChunkSize = 64
+
+// ChunkedSlice は固定サイズのチャンクを連結した両端キュー型のsliceです。
+// 通常のsliceは容量を超えると内部配列全体を新しい領域へコピーしますが、
+// ChunkedSlice はチャンクを追加するだけなので、要素のアドレスは
+// Append や PushFront の後も変わりません。
+// チャンクの一覧の前後に空きを持たせているので、Append も PushFront も償却O(1)です。
+type ChunkedSlice[T any] struct {
+	chunks    [][]T // chunks[first:] が使用中のチャンク。各チャンクは作成後に再割り当てされない
+	first     int   // 使用中の先頭チャンクの位置（chunks[:first] は PushFront 用の空き）
+	chunkSize int
+	head      int // chunks[first] 内の先頭要素の位置
+	length    int
+}
+
+// NewChunkedSlice はチャンクあたりchunkSize要素の ChunkedSlice を作成します
+func NewChunkedSlice[T any](chunkSize int) *ChunkedSlice[T] {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	return &ChunkedSlice[T]{chunkSize: chunkSize}
+}
+
+// Len は要素数を返します
+func (c *ChunkedSlice[T]) Len() int {
+	return c.length
+}
+
+// Append は末尾に要素を追加し、追加した要素へのポインタを返します
+func (c *ChunkedSlice[T]) Append(value T) *T {
+	pos := c.head + c.length
+	if c.first+pos/c.chunkSize == len(c.chunks) {
+		// 既存のチャンクは動かさず、新しいチャンクを末尾に追加
+		c.chunks = append(c.chunks, make([]T, c.chunkSize))
+	}
+	elem := c.at(pos)
+	*elem = value
+	c.length++
+	return elem
+}
+
+// PushFront は先頭に要素を追加し、追加した要素へのポインタを返します
+func (c *ChunkedSlice[T]) PushFront(value T) *T {
+	if c.head == 0 {
+		// 先頭のチャンクに空きがなければ、一覧の前の空きにチャンクを足す
+		if c.first == 0 {
+			c.growFront()
+		}
+		c.first--
+		c.chunks[c.first] = make([]T, c.chunkSize)
+		c.head = c.chunkSize
+	}
+	c.head--
+	c.length++
+	elem := &c.chunks[c.first][c.head]
+	*elem = value
+	return elem
+}
+
+// growFront はチャンクの一覧の前に、使用中のチャンクと同じ数（最低1つ）の空きを作ります。
+// 空きを倍々に増やすので、一覧のコピーは PushFront 1回あたり償却O(1)です。
+// コピーされるのはチャンクの一覧だけで、要素は動きません。
+func (c *ChunkedSlice[T]) growFront() {
+	used := c.chunks[c.first:]
+	room := max(len(used), 1)
+	chunks := make([][]T, room+len(used))
+	copy(chunks[room:], used)
+	c.chunks = chunks
+	c.first = room
+}
+
+// at は先頭チャンクの先頭からの位置 pos にある要素へのポインタを返します
+func (c *ChunkedSlice[T]) at(pos int) *T {
+	return &c.chunks[c.first+pos/c.chunkSize][pos%c.chunkSize]
+}
+
+// At は位置iの要素へのポインタを返します。範囲外の場合はpanicします。
+func (c *ChunkedSlice[T]) At(i int) *T {
+	if i < 0 || i >= c.length {
+		panic("sliceaccess: ChunkedSlice index out of range")
+	}
+	return c.at(c.head + i)
+}
+
+// Middle は真ん中の要素へのポインタをO(1)で返します
+func (c *ChunkedSlice[T]) Middle() (*T, error) {
+	if c.length == 0 {
+		return nil, ErrEmptySlice
+	}
+	return c.At(MiddleIndex(c.length)), nil
+}
+
+// All は先頭から順に位置と値を返すイテレータです
+func (c *ChunkedSlice[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < c.length; i++ {
+			if !yield(i, *c.at(c.head + i)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package sliceaccess
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+)
+
+// Append と PushFront でチャンクが増えても、先に取ったポインタは同じ要素を指し続ける
+func TestChunkedSlicePointerStability(t *testing.T) {
+	c := NewChunkedSlice[int](4)
+	first := c.Append(0)
+	if c.At(0) != first {
+		t.Fatal("At(0) differs from the pointer returned by Append")
+	}
+	ptrs := []*int{first}
+	for i := 1; i < 100; i++ {
+		ptrs = append(ptrs, c.Append(i))
+	}
+	front := c.PushFront(-1)
+	for range 10 {
+		c.PushFront(-2)
+	}
+
+	// 先頭に11要素足したので、元の要素 i は位置 i+11 にある
+	for i, p := range ptrs {
+		if got := c.At(i + 11); got != p {
+			t.Fatalf("At(%d) = %p; want the pointer %p returned by Append", i+11, got, p)
+		}
+		if *p != i {
+			t.Fatalf("*ptrs[%d] = %d; want %d", i, *p, i)
+		}
+	}
+	if c.At(10) != front || *front != -1 {
+		t.Errorf("At(10) = %p (%d); want PushFront pointer %p", c.At(10), *c.At(10), front)
+	}
+
+	*first = 42
+	if *c.At(11) != 42 {
+		t.Errorf("write through the Append pointer is not visible via At: %d", *c.At(11))
+	}
+}
+
+// チャンクの境界をまたいでも At と All は先頭から順に要素を返す
+func TestChunkedSliceOrder(t *testing.T) {
+	c := NewChunkedSlice[int](3)
+	for i := 5; i < 12; i++ {
+		c.Append(i)
+	}
+	for i := 4; i >= 0; i-- {
+		c.PushFront(i)
+	}
+	if c.Len() != 12 {
+		t.Fatalf("Len = %d; want 12", c.Len())
+	}
+	for i := range c.Len() {
+		if got := *c.At(i); got != i {
+			t.Errorf("At(%d) = %d; want %d", i, got, i)
+		}
+	}
+
+	next := 0
+	for i, v := range c.All() {
+		if i != next || v != next {
+			t.Fatalf("All yielded (%d, %d); want (%d, %d)", i, v, next, next)
+		}
+		next++
+	}
+	if next != 12 {
+		t.Errorf("All yielded %d elements; want 12", next)
+	}
+
+	// 途中で break しても続きを呼ばない
+	n := 0
+	for range c.All() {
+		n++
+		if n == 4 {
+			break
+		}
+	}
+	if n != 4 {
+		t.Errorf("All continued after break: %d", n)
+	}
+}
+
+// PushFront でチャンクの一覧を作り直す回数は要素数の対数に比例する（1回あたり償却O(1)）
+func TestChunkedSlicePushFrontAmortized(t *testing.T) {
+	const n = 4096
+	c := NewChunkedSlice[int](1) // 1要素ごとにチャンクが増える
+	reallocs := 0
+	var last *[]int
+	for i := range n {
+		c.PushFront(i)
+		if data := unsafe.SliceData(c.chunks); data != last {
+			reallocs++
+			last = data
+		}
+	}
+	if reallocs > 13 { // log2(4096) + 1
+		t.Errorf("chunk list was reallocated %d times for %d PushFront calls", reallocs, n)
+	}
+	for i := range n {
+		if got := *c.At(i); got != n-1-i {
+			t.Fatalf("At(%d) = %d; want %d", i, got, n-1-i)
+		}
+	}
+}
+
+func TestChunkedSliceMiddle(t *testing.T) {
+	c := NewChunkedSlice[string](0)
+	if p, err := c.Middle(); p != nil || !errors.Is(err, ErrEmptySlice) {
+		t.Errorf("Middle on empty = %v, %v; want nil, ErrEmptySlice", p, err)
+	}
+	for _, s := range []string{"a", "b", "c", "d"} {
+		c.Append(s)
+	}
+	if p, err := c.Middle(); err != nil || *p != "c" {
+		t.Errorf("Middle = %v, %v; want c", p, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("At out of range did not panic")
+		}
+	}()
+	c.At(4)
+}
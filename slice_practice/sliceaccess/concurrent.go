@@ -0,0 +1,109 @@
+package sliceaccess
+
+import "sync"
+
+// DefaultShards は NewConcurrentSliceOfMaps に0以下を渡したときのロックの数です
+const DefaultShards = 64
+
+// ConcurrentSliceOfMaps は複数のgoroutineから安全に読み書きできる []map[K]V です。
+//
+// 要素 i のmapは shards[i % len(shards)] の sync.RWMutex で保護されるため、
+// 別のシャードに属するmapへの書き込みは互いにブロックしません。
+// sliceそのもの（長さと内部配列）は mu で保護し、Append のときだけ書き込みロックを取ります。
+// ロックは常に mu → シャードの順に取ります。
+type ConcurrentSliceOfMaps[K comparable, V any] struct {
+	mu     sync.RWMutex
+	maps   []map[K]V
+	shards []sync.RWMutex
+}
+
+// NewConcurrentSliceOfMaps は maps を保持する ConcurrentSliceOfMaps を作成します。
+// shards はロックの数で、0以下なら DefaultShards、len(maps) 以上にすれば要素ごとのロックになります。
+// maps とその中のmapの所有権は ConcurrentSliceOfMaps に移るため、呼び出し側は以後直接触らないでください。
+func NewConcurrentSliceOfMaps[K comparable, V any](maps []map[K]V, shards int) *ConcurrentSliceOfMaps[K, V] {
+	if shards <= 0 {
+		shards = DefaultShards
+	}
+	return &ConcurrentSliceOfMaps[K, V]{
+		maps:   maps,
+		shards: make([]sync.RWMutex, shards),
+	}
+}
+
+// shard は要素 i を保護するロックを返します
+func (c *ConcurrentSliceOfMaps[K, V]) shard(i int) *sync.RWMutex {
+	return &c.shards[i%len(c.shards)]
+}
+
+// Len は要素数を返します
+func (c *ConcurrentSliceOfMaps[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.maps)
+}
+
+// Get は要素 i のmapから key の値を取得します
+func (c *ConcurrentSliceOfMaps[K, V]) Get(i int, key K) (V, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if i < 0 || i >= len(c.maps) {
+		var zero V
+		return zero, ErrIndexOutOfRange
+	}
+	return c.lookup(i, key)
+}
+
+// Middle は真ん中のmapから key の値を取得します。
+// MiddleLookup と同じく、空なら ErrEmptySlice、mapがnilなら ErrNilMap を返します。
+func (c *ConcurrentSliceOfMaps[K, V]) Middle(key K) (V, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.maps) == 0 {
+		var zero V
+		return zero, ErrEmptySlice
+	}
+	return c.lookup(MiddleIndex(len(c.maps)), key)
+}
+
+// lookup は mu の読み込みロックを取った状態で、要素 i のmapから key の値を取得します
+func (c *ConcurrentSliceOfMaps[K, V]) lookup(i int, key K) (V, error) {
+	s := c.shard(i)
+	s.RLock()
+	defer s.RUnlock()
+	return lookupIndex(c.maps, i, key)
+}
+
+// Set は要素 i のmapの key に value を設定します。mapがnilなら作成します。
+func (c *ConcurrentSliceOfMaps[K, V]) Set(i int, key K, value V) error {
+	return c.Update(i, func(m map[K]V) {
+		m[key] = value
+	})
+}
+
+// Update は要素 i のmapを書き込みロックを取った状態で fn に渡します。
+// 読んでから書く（カウンタを増やすなど）操作をまとめて行うときに使います。
+// fn に渡したmapを fn の外に持ち出してはいけません。mapがnilなら作成してから渡します。
+func (c *ConcurrentSliceOfMaps[K, V]) Update(i int, fn func(m map[K]V)) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if i < 0 || i >= len(c.maps) {
+		return ErrIndexOutOfRange
+	}
+	s := c.shard(i)
+	s.Lock()
+	defer s.Unlock()
+	if c.maps[i] == nil {
+		c.maps[i] = make(map[K]V)
+	}
+	fn(c.maps[i])
+	return nil
+}
+
+// Append は末尾にmapを追加し、そのインデックスを返します。
+// m の所有権は ConcurrentSliceOfMaps に移ります。
+func (c *ConcurrentSliceOfMaps[K, V]) Append(m map[K]V) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maps = append(c.maps, m)
+	return len(c.maps) - 1
+}
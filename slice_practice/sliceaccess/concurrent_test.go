@@ -0,0 +1,98 @@
+package sliceaccess
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSliceOfMaps(t *testing.T) {
+	c := NewConcurrentSliceOfMaps[string, int](nil, 4)
+	if _, err := c.Middle("key1"); !errors.Is(err, ErrEmptySlice) {
+		t.Errorf("Middle on empty = %v; want ErrEmptySlice", err)
+	}
+	for i := range 5 {
+		c.Append(map[string]int{"key1": i})
+	}
+	if got, err := c.Middle("key1"); got != 2 || err != nil {
+		t.Errorf("Middle = %d, %v; want 2", got, err)
+	}
+	if err := c.Set(4, "key2", 40); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := c.Get(4, "key2"); got != 40 || err != nil {
+		t.Errorf("Get(4, key2) = %d, %v; want 40", got, err)
+	}
+
+	var notFound *KeyNotFoundError
+	if _, err := c.Get(1, "missing"); !errors.As(err, &notFound) || notFound.Index != 1 {
+		t.Errorf("Get(1, missing) = %v; want KeyNotFoundError at index 1", err)
+	}
+	if _, err := c.Get(5, "key1"); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Get(5) = %v; want ErrIndexOutOfRange", err)
+	}
+
+	// nilのmapは Middle ではエラー、Set では作成される
+	c = NewConcurrentSliceOfMaps([]map[string]int{nil}, 0)
+	if _, err := c.Middle("key1"); !errors.Is(err, ErrNilMap) {
+		t.Errorf("Middle on nil map = %v; want ErrNilMap", err)
+	}
+	if err := c.Set(0, "key1", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := c.Middle("key1"); got != 1 {
+		t.Errorf("Middle after Set = %d; want 1", got)
+	}
+}
+
+// 読み込み、書き込み、追加を同時に行う。-race で実行してデータ競合がないことを確認する。
+func TestConcurrentSliceOfMapsParallel(t *testing.T) {
+	maps := make([]map[string]int, 100)
+	for i := range maps {
+		maps[i] = map[string]int{"key1": 0}
+	}
+	c := NewConcurrentSliceOfMaps(maps, 8)
+
+	const workers, ops = 8, 500
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(3)
+		// カウンタを増やす
+		go func() {
+			defer wg.Done()
+			for i := range ops {
+				c.Update(i%100, func(m map[string]int) { m["key1"]++ })
+			}
+		}()
+		// 読み込む
+		go func() {
+			defer wg.Done()
+			for i := range ops {
+				c.Middle("key1")
+				c.Get(i%c.Len(), "key1")
+			}
+		}()
+		// 追加と新しいキーの書き込み
+		go func() {
+			defer wg.Done()
+			for i := range ops / 10 {
+				idx := c.Append(map[string]int{"key1": 0})
+				c.Set(idx, fmt.Sprintf("w%d", w), i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := c.Len(); got != 100+workers*ops/10 {
+		t.Errorf("Len = %d; want %d", got, 100+workers*ops/10)
+	}
+	total := 0
+	for i := range 100 {
+		v, _ := c.Get(i, "key1")
+		total += v
+	}
+	if total != workers*ops {
+		t.Errorf("sum of counters = %d; want %d (lost updates)", total, workers*ops)
+	}
+}
@@ -0,0 +1,31 @@
+package sliceaccess
+
+import (
+	"fmt"
+	"os"
+)
+
+// StaleRefError は TrackRef で取得した参照が、所有するsliceの再割り当て後に
+// 使われたことを表します。slicedebug ビルドタグ付きでビルドしたときのみ返されます。
+type StaleRefError struct {
+	OldData uintptr // 参照取得時の内部配列の先頭アドレス
+	OldCap  int     // 参照取得時の容量
+	NewData uintptr // 使用時の内部配列の先頭アドレス
+	NewCap  int     // 使用時の容量
+
+	TakenStack []byte // 参照を取得した時点のスタックトレース
+	UsedStack  []byte // 古い参照を使った時点のスタックトレース
+}
+
+func (e *StaleRefError) Error() string {
+	return fmt.Sprintf("stale slice reference: backing array moved from %#x (cap %d) to %#x (cap %d)",
+		e.OldData, e.OldCap, e.NewData, e.NewCap)
+}
+
+// StaleRefHandler は古い参照を検出したときに呼ばれます。
+// デフォルトではスタックトレース付きで標準エラー出力に報告します。
+// テストでは t.Error などを呼ぶ関数に差し替えてください。
+var StaleRefHandler = func(err *StaleRefError) {
+	fmt.Fprintf(os.Stderr, "⚠️  %v\n--- 参照を取得した場所 ---\n%s\n--- 古い参照を使った場所 ---\n%s\n",
+		err, err.TakenStack, err.UsedStack)
+}
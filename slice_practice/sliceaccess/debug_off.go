@@ -0,0 +1,18 @@
+//go:build !slicedebug
+
+package sliceaccess
+
+// DebugEnabled は古い参照の検出が有効かどうかを表します。
+// 有効にするには -tags slicedebug を付けてビルドしてください。
+const DebugEnabled = false
+
+// TrackRef は slice[i] へのポインタを返します。
+// 通常のビルドでは &slice[i] と同じで、追加のコストはありません。
+func TrackRef[T any](slice []T, i int) *T {
+	return &slice[i]
+}
+
+// CheckRef は通常のビルドでは何も検査せず、常にnilを返します
+func CheckRef[T any](p *T, owner []T) error {
+	return nil
+}
@@ -0,0 +1,131 @@
+//go:build slicedebug
+
+package sliceaccess
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"unsafe"
+	"weak"
+)
+
+// DebugEnabled は古い参照の検出が有効かどうかを表します
+const DebugEnabled = true
+
+// maxTrackedRefs は記録しておく参照の最大数です。
+// これを超えると古い記録から捨てるので、ベンチマークのような長いループでもメモリは増え続けません。
+const maxTrackedRefs = 1024
+
+// refStackDepth は参照を取得した場所として記録する呼び出し元の深さです
+const refStackDepth = 32
+
+// refRecord は参照を取得した時点のsliceの状態です
+type refRecord struct {
+	// ref は要素への弱い参照（weak.Pointer[T]）です。記録が要素や内部配列を
+	// 到達可能に保つことはなく、回収後に同じアドレスが再利用されても取り違えません。
+	ref  any
+	data uintptr
+	cap  int
+	// pcs は呼び出し元のプログラムカウンタです。スタックトレースの文字列化は
+	// 古い参照を検出したときだけ行います。
+	pcs [refStackDepth]uintptr
+	n   int
+}
+
+// refTable は追跡中の参照（要素のアドレス → refRecord）を最大 maxTrackedRefs 件保持します
+type refTable struct {
+	mu      sync.Mutex
+	records map[uintptr]*refRecord
+	ring    [maxTrackedRefs]uintptr // 記録した順のアドレス
+	next    int
+}
+
+var refs = refTable{records: make(map[uintptr]*refRecord)}
+
+// store は addr の記録を追加または更新し、上限を超えた分は古い順に捨てます
+func (t *refTable) store(addr uintptr, rec *refRecord) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.records[addr]; !ok {
+		if old := t.ring[t.next]; old != 0 {
+			delete(t.records, old)
+		}
+		t.ring[t.next] = addr
+		t.next = (t.next + 1) % maxTrackedRefs
+	}
+	t.records[addr] = rec
+}
+
+// load は addr の記録を返します
+func (t *refTable) load(addr uintptr) (*refRecord, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rec, ok := t.records[addr]
+	return rec, ok
+}
+
+// len は保持している記録の数を返します
+func (t *refTable) len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.records)
+}
+
+// TrackRef は slice[i] へのポインタを返します。
+// slicedebug ビルドでは、内部配列のアドレス（unsafe.SliceData）と容量、
+// 呼び出し元のプログラムカウンタを記録します。
+func TrackRef[T any](slice []T, i int) *T {
+	p := &slice[i]
+	rec := &refRecord{
+		ref:  weak.Make(p),
+		data: uintptr(unsafe.Pointer(unsafe.SliceData(slice))),
+		cap:  cap(slice),
+	}
+	rec.n = runtime.Callers(2, rec.pcs[:])
+	refs.store(uintptr(unsafe.Pointer(p)), rec)
+	return p
+}
+
+// CheckRef は p が現在の owner の内部配列を指しているかを検査します。
+// 再割り当て後の古い配列を指している場合は StaleRefHandler に報告し、
+// *StaleRefError を返します。
+func CheckRef[T any](p *T, owner []T) error {
+	data := unsafe.SliceData(owner)
+	start := uintptr(unsafe.Pointer(data))
+	end := start + uintptr(cap(owner))*unsafe.Sizeof(*p)
+	addr := uintptr(unsafe.Pointer(p))
+	if data != nil && addr >= start && addr < end {
+		return nil
+	}
+
+	err := &StaleRefError{
+		NewData:   start,
+		NewCap:    cap(owner),
+		UsedStack: debug.Stack(),
+	}
+	// 同じアドレスの記録でも、弱い参照が p を指していなければ別の要素の記録なので使わない
+	if rec, ok := refs.load(addr); ok {
+		if ref, ok := rec.ref.(weak.Pointer[T]); ok && ref.Value() == p {
+			err.OldData = rec.data
+			err.OldCap = rec.cap
+			err.TakenStack = formatStack(rec.pcs[:rec.n])
+		}
+	}
+	StaleRefHandler(err)
+	return err
+}
+
+// formatStack は runtime.Callers で取得したプログラムカウンタを debug.Stack に近い形式で文字列化します
+func formatStack(pcs []uintptr) []byte {
+	var b []byte
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		b = fmt.Appendf(b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			return b
+		}
+	}
+}
@@ -0,0 +1,68 @@
+//go:build slicedebug
+
+package sliceaccess
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+)
+
+func TestCheckRefDetectsReallocation(t *testing.T) {
+	handler := StaleRefHandler
+	defer func() { StaleRefHandler = handler }()
+	var reported *StaleRefError
+	StaleRefHandler = func(err *StaleRefError) { reported = err }
+
+	slice := []int{0, 10, 20}
+	p := TrackRef(slice, 1)
+
+	// 容量内のsliceを使っている間は有効
+	if err := CheckRef(p, slice); err != nil {
+		t.Fatalf("CheckRef before append = %v, want nil", err)
+	}
+
+	slice = append(slice, make([]int, 1000)...)
+	err := CheckRef(p, slice)
+	var stale *StaleRefError
+	if !errors.As(err, &stale) {
+		t.Fatalf("CheckRef after reallocation = %v, want *StaleRefError", err)
+	}
+	if reported != stale {
+		t.Error("StaleRefHandler was not called with the returned error")
+	}
+	if stale.OldCap != 3 || stale.NewCap != cap(slice) {
+		t.Errorf("caps = %d -> %d, want 3 -> %d", stale.OldCap, stale.NewCap, cap(slice))
+	}
+	if len(stale.TakenStack) == 0 || len(stale.UsedStack) == 0 {
+		t.Error("stack traces were not recorded")
+	}
+}
+
+// 長いループで TrackRef を呼び続けても記録は上限を超えず、古い内部配列も回収される
+func TestTrackRefIsBounded(t *testing.T) {
+	for range 10 {
+		slice := make([]int, 1000)
+		for i := range slice {
+			TrackRef(slice, i)
+		}
+	}
+	if n := refs.len(); n > maxTrackedRefs {
+		t.Errorf("tracked %d refs; want at most %d", n, maxTrackedRefs)
+	}
+
+	collected := make(chan struct{})
+	slice := make([]int, 1<<16)
+	runtime.AddCleanup(&slice[0], func(struct{}) { close(collected) }, struct{}{})
+	TrackRef(slice, 1)
+	slice = nil
+	for range 10 {
+		runtime.GC()
+		select {
+		case <-collected:
+			return
+		default:
+		}
+	}
+	t.Error("backing array tracked by TrackRef was not collected")
+}
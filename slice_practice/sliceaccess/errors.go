@@ -0,0 +1,31 @@
+package sliceaccess
+
+import (
+	"errors"
+	"fmt"
+)
+
+// アクセス失敗の原因を errors.Is で判別するためのセンチネルエラー
+var (
+	// ErrEmptySlice はsliceが空で真ん中の要素が存在しないことを表します
+	ErrEmptySlice = errors.New("slice is empty")
+	// ErrNilMap は真ん中のインデックスにあるmapがnilであることを表します
+	ErrNilMap = errors.New("map at middle index is nil")
+)
+
+// KeyNotFoundError は真ん中のmapに指定したキーが存在しないことを表します。
+// errors.As で取り出すと、見つからなかったキーとmapのインデックスを参照できます。
+type KeyNotFoundError struct {
+	Key   any
+	Index int
+}
+
+func (e *KeyNotFoundError) Error() string {
+	return fmt.Sprintf("key '%v' not found in map at index %d", e.Key, e.Index)
+}
+
+// ErrStaleHandle はハンドルが指す要素が既に削除されていることを表します
+var ErrStaleHandle = errors.New("handle refers to a removed element")
+
+// ErrIndexOutOfRange はインデックスが要素数の範囲外であることを表します
+var ErrIndexOutOfRange = errors.New("index out of range")
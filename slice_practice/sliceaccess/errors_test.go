@@ -0,0 +1,34 @@
+package sliceaccess
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// 呼び出し側で %w で包んでも、原因を errors.Is / errors.As で判別できる
+func TestErrorsWrapped(t *testing.T) {
+	_, err := MiddleLookup([]map[string]int{nil}, "a")
+	if wrapped := fmt.Errorf("load config: %w", err); !errors.Is(wrapped, ErrNilMap) || errors.Is(wrapped, ErrEmptySlice) {
+		t.Errorf("wrapped nil map error = %v; want only ErrNilMap", wrapped)
+	}
+
+	_, err = MiddleLookup([]map[int]string{{1: "x"}, {2: "y"}}, 3)
+	var notFound *KeyNotFoundError
+	if wrapped := fmt.Errorf("load config: %w", err); !errors.As(wrapped, &notFound) {
+		t.Fatalf("wrapped missing key error = %v; want KeyNotFoundError", wrapped)
+	}
+	if notFound.Key != 3 || notFound.Index != 1 {
+		t.Errorf("KeyNotFoundError = %+v; want Key 3, Index 1", notFound)
+	}
+	if errors.Is(err, ErrNilMap) || errors.Is(err, ErrEmptySlice) {
+		t.Errorf("KeyNotFoundError matches a sentinel error: %v", err)
+	}
+}
+
+func TestKeyNotFoundErrorMessage(t *testing.T) {
+	err := &KeyNotFoundError{Key: "key1", Index: 500}
+	if got, want := err.Error(), "key 'key1' not found in map at index 500"; got != want {
+		t.Errorf("Error() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,135 @@
+package sliceaccess
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"text/tabwriter"
+	"unsafe"
+)
+
+// Growth は append による1回の再割り当ての記録です
+type Growth struct {
+	Len         int     // 再割り当てを起こした append の後の長さ
+	OldCap      int     // 再割り当て前の容量
+	NewCap      int     // 再割り当て後の容量
+	OldData     uintptr // 再割り当て前のデータポインタ（容量0なら0）
+	NewData     uintptr // 再割り当て後のデータポインタ
+	BytesCopied int     // 新しい内部配列へコピーされたバイト数（古い長さ × 要素サイズ）
+	AllocBytes  int     // 新しい内部配列のバイト数（新しい容量 × 要素サイズ）
+	Factor      float64 // NewCap / OldCap（OldCap が0なら0）
+}
+
+// String は1行のログとして Growth を整形します
+func (g Growth) String() string {
+	return fmt.Sprintf("len=%d cap %d→%d (x%.2f) data %#x→%#x copied=%dB", g.Len, g.OldCap, g.NewCap, g.Factor, g.OldData, g.NewData, g.BytesCopied)
+}
+
+// newGrowth は容量が oldCap から newCap に変わった再割り当ての記録を作成します
+func newGrowth(length, oldLen, oldCap, newCap int, oldData, newData uintptr, elemSize int) Growth {
+	g := Growth{
+		Len:         length,
+		OldCap:      oldCap,
+		NewCap:      newCap,
+		OldData:     oldData,
+		NewData:     newData,
+		BytesCopied: oldLen * elemSize,
+		AllocBytes:  newCap * elemSize,
+	}
+	if oldCap > 0 {
+		g.Factor = float64(newCap) / float64(oldCap)
+	}
+	return g
+}
+
+// GrowthTracer は append を計測用の経路に通し、内部配列の再割り当てをすべて記録する
+// sliceのラッパーです。
+//
+// 通常の append と同じく、再割り当ての後は以前に取得した要素へのポインタが古い内部配列を
+// 指したままになります。GrowthTracer はそれがいつ起きたかを見えるようにします。
+type GrowthTracer[T any] struct {
+	s        []T
+	growths  []Growth
+	onGrowth func(Growth)
+}
+
+// NewGrowthTracer は s への append を記録する GrowthTracer を作成します
+func NewGrowthTracer[T any](s []T) *GrowthTracer[T] {
+	return &GrowthTracer[T]{s: s}
+}
+
+// OnGrowth は再割り当てのたびに呼ばれる関数を設定します（ログの出力などに使います）
+func (t *GrowthTracer[T]) OnGrowth(fn func(Growth)) {
+	t.onGrowth = fn
+}
+
+// Append は values を追加し、追加後のsliceを返します。
+// 内部配列が再割り当てされた場合はそれを記録します。
+func (t *GrowthTracer[T]) Append(values ...T) []T {
+	oldLen, oldCap := len(t.s), cap(t.s)
+	oldData := uintptr(unsafe.Pointer(unsafe.SliceData(t.s)))
+	t.s = append(t.s, values...)
+	newData := uintptr(unsafe.Pointer(unsafe.SliceData(t.s)))
+	if cap(t.s) != oldCap || newData != oldData {
+		var zero T
+		g := newGrowth(len(t.s), oldLen, oldCap, cap(t.s), oldData, newData, int(unsafe.Sizeof(zero)))
+		t.growths = append(t.growths, g)
+		if t.onGrowth != nil {
+			t.onGrowth(g)
+		}
+	}
+	return t.s
+}
+
+// Slice は現在のsliceを返します
+func (t *GrowthTracer[T]) Slice() []T {
+	return t.s
+}
+
+// Growths はこれまでに記録した再割り当てを返します
+func (t *GrowthTracer[T]) Growths() []Growth {
+	return t.growths
+}
+
+// GrowthCurve は要素の型が elem のsliceに、空の状態から1要素ずつ n 回 append したときの
+// 再割り当てを実際のランタイムで記録します。reflect.Append は append と同じ runtime.growslice を
+// 使うため、任意の要素の型についてGoの容量の伸び方を確認できます。
+//
+// 容量の切り上げ方は要素サイズだけでなく、要素がポインタを含むかどうか（512 bytes超の割り当てに
+// アロケータのヘッダが付くか）でも変わるため、サイズではなく型を受け取ります。
+func GrowthCurve(elem reflect.Type, n int) []Growth {
+	elemSize := int(elem.Size())
+	s := reflect.MakeSlice(reflect.SliceOf(elem), 0, 0)
+	zero := reflect.Zero(elem)
+	var growths []Growth
+	for i := 0; i < n; i++ {
+		oldLen, oldCap, oldData := s.Len(), s.Cap(), dataPointer(s)
+		s = reflect.Append(s, zero)
+		if s.Cap() != oldCap || dataPointer(s) != oldData {
+			growths = append(growths, newGrowth(s.Len(), oldLen, oldCap, s.Cap(), oldData, dataPointer(s), elemSize))
+		}
+	}
+	return growths
+}
+
+// dataPointer は s のデータポインタを返します（容量0なら0）
+func dataPointer(s reflect.Value) uintptr {
+	if s.Cap() == 0 {
+		return 0
+	}
+	return uintptr(s.UnsafePointer())
+}
+
+// WriteGrowthTable は再割り当ての記録を表として書き出します
+func WriteGrowthTable(w io.Writer, growths []Growth) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  len\told cap\tnew cap\tfactor\tcopied\talloc")
+	for _, g := range growths {
+		factor := "-"
+		if g.OldCap > 0 {
+			factor = fmt.Sprintf("%.2f", g.Factor)
+		}
+		fmt.Fprintf(tw, "  %d\t%d\t%d\t%s\t%dB\t%dB\n", g.Len, g.OldCap, g.NewCap, factor, g.BytesCopied, g.AllocBytes)
+	}
+	return tw.Flush()
+}
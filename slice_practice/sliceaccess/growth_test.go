@@ -0,0 +1,97 @@
+package sliceaccess
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestGrowthTracer(t *testing.T) {
+	tracer := NewGrowthTracer(make([]int64, 3, 4))
+	var logged []Growth
+	tracer.OnGrowth(func(g Growth) { logged = append(logged, g) })
+
+	// 容量内の append は再割り当てを起こさない
+	before := unsafe.SliceData(tracer.Slice())
+	tracer.Append(1)
+	if len(tracer.Growths()) != 0 || unsafe.SliceData(tracer.Slice()) != before {
+		t.Fatalf("append within capacity recorded %v", tracer.Growths())
+	}
+
+	s := tracer.Append(2)
+	growths := tracer.Growths()
+	if len(growths) != 1 || len(logged) != 1 {
+		t.Fatalf("got %d growths, %d logged; want 1", len(growths), len(logged))
+	}
+	g := growths[0]
+	if g.Len != 5 || g.OldCap != 4 || g.NewCap != cap(s) || g.NewCap <= 4 {
+		t.Errorf("growth = %+v", g)
+	}
+	if g.OldData != uintptr(unsafe.Pointer(before)) || g.NewData != uintptr(unsafe.Pointer(unsafe.SliceData(s))) {
+		t.Errorf("data pointers = %#x→%#x", g.OldData, g.NewData)
+	}
+	if g.BytesCopied != 4*8 || g.AllocBytes != g.NewCap*8 || g.Factor != float64(g.NewCap)/4 {
+		t.Errorf("growth = %+v", g)
+	}
+}
+
+var growthSink []int64
+
+func TestGrowthCurve(t *testing.T) {
+	growths := GrowthCurve(reflect.TypeFor[int64](), 1000)
+	if len(growths) == 0 || growths[0].OldCap != 0 {
+		t.Fatalf("growths = %v", growths)
+	}
+	// 実際の append と同じ容量の列になる。Go 1.25 以降はエスケープしない小さなsliceの
+	// 最初の内部配列をスタックに置くことがあるため、growthSink に代入してヒープに置く。
+	var caps []int
+	for range 1000 {
+		old := cap(growthSink)
+		growthSink = append(growthSink, 0)
+		if cap(growthSink) != old {
+			caps = append(caps, cap(growthSink))
+		}
+	}
+	growthSink = nil
+	if len(caps) != len(growths) {
+		t.Fatalf("got %d growths, append grew %d times", len(growths), len(caps))
+	}
+	for i, g := range growths {
+		if g.NewCap != caps[i] {
+			t.Errorf("growth %d: cap %d, append gave %d", i, g.NewCap, caps[i])
+		}
+	}
+
+	var buf bytes.Buffer
+	WriteGrowthTable(&buf, growths)
+	if lines := strings.Count(buf.String(), "\n"); lines != len(growths)+1 {
+		t.Errorf("table has %d lines, want %d", lines, len(growths)+1)
+	}
+}
+
+var mapGrowthSink []map[string]int
+
+// ポインタを含む要素では512 bytesを超える割り当てにヘッダが付くため、容量の伸び方が変わる
+func TestGrowthCurvePointers(t *testing.T) {
+	const n = 2000
+	growths := GrowthCurve(reflect.TypeFor[map[string]int](), n)
+	var caps []int
+	for range n {
+		old := cap(mapGrowthSink)
+		mapGrowthSink = append(mapGrowthSink, nil)
+		if cap(mapGrowthSink) != old {
+			caps = append(caps, cap(mapGrowthSink))
+		}
+	}
+	mapGrowthSink = nil
+	if len(caps) != len(growths) {
+		t.Fatalf("got %d growths, append grew %d times", len(growths), len(caps))
+	}
+	for i, g := range growths {
+		if g.NewCap != caps[i] {
+			t.Errorf("growth %d: cap %d, append gave %d", i, g.NewCap, caps[i])
+		}
+	}
+}
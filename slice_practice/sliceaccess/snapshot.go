@@ -0,0 +1,131 @@
+package sliceaccess
+
+import (
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// Snapshot は SnapshotSliceOfMaps のある時点の内容です。
+// 公開された後は変更されないため、ロックなしで何度でも読めます。
+type Snapshot[K comparable, V any] struct {
+	maps    []map[K]V
+	version uint64
+}
+
+// Len は要素数を返します
+func (s *Snapshot[K, V]) Len() int {
+	return len(s.maps)
+}
+
+// Version はこのスナップショットを公開した順番（最初が1）を返します
+func (s *Snapshot[K, V]) Version() uint64 {
+	return s.version
+}
+
+// Get は要素 i のmapから key の値を取得します
+func (s *Snapshot[K, V]) Get(i int, key K) (V, error) {
+	if i < 0 || i >= len(s.maps) {
+		var zero V
+		return zero, ErrIndexOutOfRange
+	}
+	return lookupIndex(s.maps, i, key)
+}
+
+// Middle は真ん中のmapから key の値を取得します。
+// MiddleLookup と同じく、空なら ErrEmptySlice、mapがnilなら ErrNilMap を返します。
+func (s *Snapshot[K, V]) Middle(key K) (V, error) {
+	return MiddleLookup(s.maps, key)
+}
+
+// lookupIndex は slice[i] のmapから key の値を取得します
+func lookupIndex[K comparable, V any](slice []map[K]V, i int, key K) (V, error) {
+	return Lookup(slice[i], key, i)
+}
+
+// SnapshotSliceOfMaps は読み込みが多い用途向けの、コピーオンライトの []map[K]V です。
+//
+// 読み手は atomic.Pointer から不変の Snapshot を取り出すだけなので、ロックを一切取りません。
+// 書き手は変更する部分（sliceと対象のmap）をコピーした新しい版を作り、atomic に公開します。
+// 書き込みは1回ごとにsliceの長さに比例するコピーが発生するため、書き込みが多い場合は
+// ConcurrentSliceOfMaps を使ってください。
+type SnapshotSliceOfMaps[K comparable, V any] struct {
+	current atomic.Pointer[Snapshot[K, V]]
+	mu      sync.Mutex // 書き手どうしを直列化する
+}
+
+// NewSnapshotSliceOfMaps は maps を最初の版として公開した SnapshotSliceOfMaps を作成します。
+// maps とその中のmapの所有権は SnapshotSliceOfMaps に移るため、呼び出し側は以後変更しないでください。
+func NewSnapshotSliceOfMaps[K comparable, V any](maps []map[K]V) *SnapshotSliceOfMaps[K, V] {
+	c := &SnapshotSliceOfMaps[K, V]{}
+	c.current.Store(&Snapshot[K, V]{maps: maps, version: 1})
+	return c
+}
+
+// Load は現在の版を返します。同じ版から続けて読めば、途中の書き込みの影響を受けません。
+func (c *SnapshotSliceOfMaps[K, V]) Load() *Snapshot[K, V] {
+	return c.current.Load()
+}
+
+// Middle は現在の版の真ん中のmapから key の値を取得します
+func (c *SnapshotSliceOfMaps[K, V]) Middle(key K) (V, error) {
+	return c.Load().Middle(key)
+}
+
+// Get は現在の版の要素 i のmapから key の値を取得します
+func (c *SnapshotSliceOfMaps[K, V]) Get(i int, key K) (V, error) {
+	return c.Load().Get(i, key)
+}
+
+// publish は maps を新しい版として公開します。c.mu を取った状態で呼びます。
+func (c *SnapshotSliceOfMaps[K, V]) publish(old *Snapshot[K, V], maps []map[K]V) {
+	c.current.Store(&Snapshot[K, V]{maps: maps, version: old.version + 1})
+}
+
+// Publish は maps を新しい版としてそのまま公開します。
+// 新しい内容を別に組み立ててから一度に差し替えるときに使います。
+func (c *SnapshotSliceOfMaps[K, V]) Publish(maps []map[K]V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.publish(c.Load(), maps)
+}
+
+// Set は要素 i のmapの key を value にした新しい版を公開します
+func (c *SnapshotSliceOfMaps[K, V]) Set(i int, key K, value V) error {
+	return c.Update(i, func(m map[K]V) {
+		m[key] = value
+	})
+}
+
+// Update は要素 i のmapのコピーを fn で変更し、それを含む新しい版を公開します。
+// 古い版を読んでいる読み手には影響しません。
+func (c *SnapshotSliceOfMaps[K, V]) Update(i int, fn func(m map[K]V)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old := c.Load()
+	if i < 0 || i >= len(old.maps) {
+		return ErrIndexOutOfRange
+	}
+	m := maps.Clone(old.maps[i])
+	if m == nil {
+		m = make(map[K]V)
+	}
+	fn(m)
+	next := make([]map[K]V, len(old.maps))
+	copy(next, old.maps)
+	next[i] = m
+	c.publish(old, next)
+	return nil
+}
+
+// Append は末尾にmapを追加した新しい版を公開し、追加したインデックスを返します。
+// m の所有権は SnapshotSliceOfMaps に移ります。
+func (c *SnapshotSliceOfMaps[K, V]) Append(m map[K]V) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old := c.Load()
+	// 古い版は old.maps[:len] しか見ないので、容量の空きに書き込んでも読み手とは競合しない
+	next := append(old.maps, m)
+	c.publish(old, next)
+	return len(next) - 1
+}
@@ -0,0 +1,85 @@
+package sliceaccess
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestSnapshotSliceOfMaps(t *testing.T) {
+	c := NewSnapshotSliceOfMaps([]map[string]int{{"key1": 0}, {"key1": 1}, {"key1": 2}})
+	old := c.Load()
+	if got, err := c.Middle("key1"); got != 1 || err != nil {
+		t.Errorf("Middle = %d, %v; want 1", got, err)
+	}
+
+	if err := c.Set(1, "key1", 10); err != nil {
+		t.Fatal(err)
+	}
+	idx := c.Append(map[string]int{"key1": 3})
+
+	// 古い版は書き込みの影響を受けない
+	if got, _ := old.Middle("key1"); got != 1 || old.Len() != 3 || old.Version() != 1 {
+		t.Errorf("old snapshot changed: middle %d, len %d, version %d", got, old.Len(), old.Version())
+	}
+	cur := c.Load()
+	if cur.Version() != 3 || cur.Len() != 4 || idx != 3 {
+		t.Errorf("current: version %d, len %d, appended at %d", cur.Version(), cur.Len(), idx)
+	}
+	if got, _ := cur.Get(1, "key1"); got != 10 {
+		t.Errorf("Get(1) = %d; want 10", got)
+	}
+	if _, err := c.Get(4, "key1"); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Get(4) = %v; want ErrIndexOutOfRange", err)
+	}
+	if err := c.Update(-1, func(map[string]int) {}); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Errorf("Update(-1) = %v; want ErrIndexOutOfRange", err)
+	}
+
+	c.Publish(nil)
+	if _, err := c.Middle("key1"); !errors.Is(err, ErrEmptySlice) {
+		t.Errorf("Middle after Publish(nil) = %v; want ErrEmptySlice", err)
+	}
+}
+
+// 書き込み中も読み手は常に一貫した版を読む。-race で実行してデータ競合がないことを確認する。
+func TestSnapshotSliceOfMapsParallel(t *testing.T) {
+	maps := make([]map[string]int, 11)
+	for i := range maps {
+		maps[i] = map[string]int{"key1": 0, "key2": 0}
+	}
+	c := NewSnapshotSliceOfMaps(maps)
+
+	const writers, writes = 4, 200
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range writes {
+				// key1 と key2 を同じ版で更新する
+				c.Update(5, func(m map[string]int) {
+					m["key1"]++
+					m["key2"]++
+				})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range writes {
+				s := c.Load()
+				k1, _ := s.Get(5, "key1")
+				k2, _ := s.Get(5, "key2")
+				if k1 != k2 {
+					t.Errorf("torn read in version %d: key1=%d key2=%d", s.Version(), k1, k2)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, _ := c.Middle("key1"); got != writers*writes {
+		t.Errorf("Middle = %d; want %d (lost updates)", got, writers*writes)
+	}
+}
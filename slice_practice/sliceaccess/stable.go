@@ -0,0 +1,141 @@
+package sliceaccess
+
+// Handle は StableSlice の要素を指すハンドルです。
+// ポインタではなく「スロット番号 + 世代番号」を保持するため、
+// 内部配列がappendで再割り当てされても常に最新の要素を解決できます。
+type Handle struct {
+	index      int
+	generation uint32
+}
+
+// slot は StableSlice の内部で1要素を保持します
+type slot[T any] struct {
+	value      T
+	generation uint32
+	live       bool
+}
+
+// StableSlice は要素へのハンドルを払い出すsliceです。
+// &slice[i] のようなポインタはappendによる再割り当てで古くなりますが、
+// Handle は参照のたびに現在の内部配列から要素を引き直すため安全です。
+// 削除された要素のハンドルは、同じスロットが再利用されても ErrStaleHandle を返します。
+type StableSlice[T any] struct {
+	slots []slot[T]
+	free  []int // 再利用可能なスロット番号
+	order []int // 論理的な並び順（スロット番号）
+}
+
+// NewStableSlice は初期容量capacityの StableSlice を作成します
+func NewStableSlice[T any](capacity int) *StableSlice[T] {
+	return &StableSlice[T]{
+		slots: make([]slot[T], 0, capacity),
+		order: make([]int, 0, capacity),
+	}
+}
+
+// Len は有効な要素の数を返します
+func (s *StableSlice[T]) Len() int {
+	return len(s.order)
+}
+
+// Append は末尾に要素を追加し、その要素のハンドルを返します
+func (s *StableSlice[T]) Append(value T) Handle {
+	var index int
+	if n := len(s.free); n > 0 {
+		// 削除済みスロットを再利用（世代番号は Remove で進めてある）
+		index = s.free[n-1]
+		s.free = s.free[:n-1]
+		s.slots[index].value = value
+		s.slots[index].live = true
+	} else {
+		// 世代番号は1から始め、ゼロ値の Handle を無効にする
+		index = len(s.slots)
+		s.slots = append(s.slots, slot[T]{value: value, generation: 1, live: true})
+	}
+	s.order = append(s.order, index)
+	return Handle{index: index, generation: s.slots[index].generation}
+}
+
+// lookup はハンドルに対応するスロットを返します
+func (s *StableSlice[T]) lookup(h Handle) (*slot[T], error) {
+	if h.index < 0 || h.index >= len(s.slots) {
+		return nil, ErrStaleHandle
+	}
+	sl := &s.slots[h.index]
+	if !sl.live || sl.generation != h.generation {
+		return nil, ErrStaleHandle
+	}
+	return sl, nil
+}
+
+// Get はハンドルが指す要素の値を返します
+func (s *StableSlice[T]) Get(h Handle) (T, error) {
+	sl, err := s.lookup(h)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return sl.value, nil
+}
+
+// Ref はハンドルが指す要素へのポインタを返します。
+// 返したポインタは次の Append までしか有効ではないため、保持せずにその場で使ってください。
+func (s *StableSlice[T]) Ref(h Handle) (*T, error) {
+	sl, err := s.lookup(h)
+	if err != nil {
+		return nil, err
+	}
+	return &sl.value, nil
+}
+
+// Set はハンドルが指す要素を置き換えます
+func (s *StableSlice[T]) Set(h Handle, value T) error {
+	sl, err := s.lookup(h)
+	if err != nil {
+		return err
+	}
+	sl.value = value
+	return nil
+}
+
+// Remove はハンドルが指す要素を削除します。
+// 削除後、このハンドルを使った操作はすべて ErrStaleHandle を返します。
+func (s *StableSlice[T]) Remove(h Handle) error {
+	sl, err := s.lookup(h)
+	if err != nil {
+		return err
+	}
+
+	// 古い値を参照し続けないようにゼロ値で上書きし、世代を進める
+	var zero T
+	sl.value = zero
+	sl.live = false
+	sl.generation++
+	s.free = append(s.free, h.index)
+
+	for i, index := range s.order {
+		if index == h.index {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// At は論理的な位置iにある要素のハンドルを返します
+func (s *StableSlice[T]) At(i int) (Handle, bool) {
+	if i < 0 || i >= len(s.order) {
+		return Handle{}, false
+	}
+	index := s.order[i]
+	return Handle{index: index, generation: s.slots[index].generation}, true
+}
+
+// Middle は真ん中の要素のハンドルを返します
+func (s *StableSlice[T]) Middle() (Handle, error) {
+	if len(s.order) == 0 {
+		return Handle{}, ErrEmptySlice
+	}
+	h, _ := s.At(MiddleIndex(len(s.order)))
+	return h, nil
+}
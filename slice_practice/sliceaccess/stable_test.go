@@ -0,0 +1,126 @@
+package sliceaccess
+
+import (
+	"errors"
+	"testing"
+)
+
+// 内部配列が何度再割り当てされても、最初に取ったハンドルは同じ要素を指す
+func TestStableSliceHandleSurvivesGrowth(t *testing.T) {
+	s := NewStableSlice[int](1)
+	first := s.Append(100)
+	ref, err := s.Ref(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldRef := ref
+
+	for i := range 1000 {
+		s.Append(i)
+	}
+	if got, err := s.Get(first); got != 100 || err != nil {
+		t.Errorf("Get(first) after growth = %d, %v; want 100", got, err)
+	}
+	if ref, _ = s.Ref(first); ref == oldRef {
+		t.Fatal("backing array was not reallocated; test does not exercise growth")
+	}
+	if err := s.Set(first, 200); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Get(first); got != 200 {
+		t.Errorf("Get(first) after Set = %d; want 200", got)
+	}
+}
+
+func TestStableSliceRemove(t *testing.T) {
+	s := NewStableSlice[string](0)
+	a := s.Append("a")
+	b := s.Append("b")
+	if err := s.Remove(a); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len = %d; want 1", s.Len())
+	}
+	if _, err := s.Get(a); !errors.Is(err, ErrStaleHandle) {
+		t.Errorf("Get after Remove = %v; want ErrStaleHandle", err)
+	}
+	if _, err := s.Ref(a); !errors.Is(err, ErrStaleHandle) {
+		t.Errorf("Ref after Remove = %v; want ErrStaleHandle", err)
+	}
+	if err := s.Set(a, "x"); !errors.Is(err, ErrStaleHandle) {
+		t.Errorf("Set after Remove = %v; want ErrStaleHandle", err)
+	}
+	if err := s.Remove(a); !errors.Is(err, ErrStaleHandle) {
+		t.Errorf("second Remove = %v; want ErrStaleHandle", err)
+	}
+	if got, err := s.Get(b); got != "b" || err != nil {
+		t.Errorf("Get(b) = %q, %v; want b", got, err)
+	}
+	if _, err := s.Get(Handle{}); !errors.Is(err, ErrStaleHandle) {
+		t.Errorf("Get(zero Handle) = %v; want ErrStaleHandle", err)
+	}
+}
+
+// 削除したスロットが再利用されると世代が進むため、古いハンドルは新しい要素を指さない
+func TestStableSliceReusedSlot(t *testing.T) {
+	s := NewStableSlice[string](0)
+	old := s.Append("old")
+	if err := s.Remove(old); err != nil {
+		t.Fatal(err)
+	}
+	reused := s.Append("new")
+	if reused.index != old.index {
+		t.Fatalf("slot %d was not reused (got %d)", old.index, reused.index)
+	}
+	if reused.generation == old.generation {
+		t.Errorf("reused slot kept generation %d", old.generation)
+	}
+	if _, err := s.Get(old); !errors.Is(err, ErrStaleHandle) {
+		t.Errorf("Get(old) after reuse = %v; want ErrStaleHandle", err)
+	}
+	if got, err := s.Get(reused); got != "new" || err != nil {
+		t.Errorf("Get(reused) = %q, %v; want new", got, err)
+	}
+}
+
+func TestStableSliceAtMiddle(t *testing.T) {
+	s := NewStableSlice[int](0)
+	if _, err := s.Middle(); !errors.Is(err, ErrEmptySlice) {
+		t.Errorf("Middle on empty = %v; want ErrEmptySlice", err)
+	}
+	if _, ok := s.At(0); ok {
+		t.Error("At(0) on empty returned ok")
+	}
+
+	handles := make([]Handle, 5)
+	for i := range handles {
+		handles[i] = s.Append(i * 10)
+	}
+	for i, want := range handles {
+		if h, ok := s.At(i); !ok || h != want {
+			t.Errorf("At(%d) = %v, %v; want %v", i, h, ok, want)
+		}
+	}
+	for _, i := range []int{-1, 5} {
+		if _, ok := s.At(i); ok {
+			t.Errorf("At(%d) returned ok", i)
+		}
+	}
+	h, err := s.Middle()
+	if got, _ := s.Get(h); err != nil || got != 20 {
+		t.Errorf("Middle = %d, %v; want 20", got, err)
+	}
+
+	// 削除すると論理的な並び順が詰められる
+	if err := s.Remove(handles[0]); err != nil {
+		t.Fatal(err)
+	}
+	if h, _ := s.At(0); h != handles[1] {
+		t.Errorf("At(0) after Remove = %v; want %v", h, handles[1])
+	}
+	h, _ = s.Middle()
+	if got, _ := s.Get(h); got != 30 {
+		t.Errorf("Middle after Remove = %d; want 30", got)
+	}
+}
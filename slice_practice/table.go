@@ -0,0 +1,127 @@
+package main
+
+import "slice_practice/sliceaccess"
+
+// LargeStructTable は LargeStruct の列指向（struct of arrays）版です。
+//
+// []LargeStruct では各要素が8KBの Data を含むため、ID だけを走査しても
+// 関係のない Data がキャッシュラインごと読み込まれます。LargeStructTable は
+// フィールドごとに別のsliceに持つので、ID の走査は IDs を順に読むだけで済みます。
+// i 行目は各列の i 番目の要素で表します。
+type LargeStructTable struct {
+	IDs      []int
+	Names    []string
+	Data     [][1000]int
+	Metadata []map[string]interface{}
+	Values   [][]float64
+}
+
+// NewLargeStructTable は容量 capacity の空の LargeStructTable を作成します
+func NewLargeStructTable(capacity int) *LargeStructTable {
+	return &LargeStructTable{
+		IDs:      make([]int, 0, capacity),
+		Names:    make([]string, 0, capacity),
+		Data:     make([][1000]int, 0, capacity),
+		Metadata: make([]map[string]interface{}, 0, capacity),
+		Values:   make([][]float64, 0, capacity),
+	}
+}
+
+// NewLargeStructTableFrom は slice の内容を列ごとにコピーした LargeStructTable を作成します。
+// Metadata と Values は参照先を共有します。
+func NewLargeStructTableFrom(slice []LargeStruct) *LargeStructTable {
+	t := NewLargeStructTable(len(slice))
+	for i := range slice {
+		t.Append(&slice[i])
+	}
+	return t
+}
+
+// Len は行数を返します
+func (t *LargeStructTable) Len() int {
+	return len(t.IDs)
+}
+
+// Append は s を末尾の行として追加し、その行を返します
+func (t *LargeStructTable) Append(s *LargeStruct) LargeStructRow {
+	t.IDs = append(t.IDs, s.ID)
+	t.Names = append(t.Names, s.Name)
+	t.Data = append(t.Data, s.Data)
+	t.Metadata = append(t.Metadata, s.Metadata)
+	t.Values = append(t.Values, s.Values)
+	return t.Row(t.Len() - 1)
+}
+
+// Row は i 行目のビューを返します。i が範囲外ならパニックします（sliceの添字と同じ）。
+func (t *LargeStructTable) Row(i int) LargeStructRow {
+	_ = t.IDs[i]
+	return LargeStructRow{table: t, index: i}
+}
+
+// Middle は真ん中の行のビューを返します
+func (t *LargeStructTable) Middle() (LargeStructRow, error) {
+	if t.Len() == 0 {
+		return LargeStructRow{}, sliceaccess.ErrEmptySlice
+	}
+	return t.Row(t.Len() / 2), nil
+}
+
+// ToSlice は LargeStructTable を []LargeStruct に変換します。
+// Metadata と Values は参照先を共有します。
+func (t *LargeStructTable) ToSlice() []LargeStruct {
+	slice := make([]LargeStruct, t.Len())
+	for i := range slice {
+		t.Row(i).CopyTo(&slice[i])
+	}
+	return slice
+}
+
+// LargeStructRow は LargeStructTable の1行のビューです。
+// テーブルとインデックスを保持するため、Append で列が再割り当てされた後も
+// 常に現在の列から値を読みます。
+type LargeStructRow struct {
+	table *LargeStructTable
+	index int
+}
+
+// Index は行番号を返します
+func (r LargeStructRow) Index() int { return r.index }
+
+// ID は ID 列の値を返します
+func (r LargeStructRow) ID() int { return r.table.IDs[r.index] }
+
+// Name は Name 列の値を返します
+func (r LargeStructRow) Name() string { return r.table.Names[r.index] }
+
+// Data は Data 列の要素へのポインタを返します。8KBのコピーを避けるためポインタを返しますが、
+// &slice[i] と同じく、テーブルへの Append の後は古い配列を指す可能性があります。
+func (r LargeStructRow) Data() *[1000]int { return &r.table.Data[r.index] }
+
+// Metadata は Metadata 列の値を返します
+func (r LargeStructRow) Metadata() map[string]interface{} { return r.table.Metadata[r.index] }
+
+// Values は Values 列の値を返します
+func (r LargeStructRow) Values() []float64 { return r.table.Values[r.index] }
+
+// SetID は ID 列の値を設定します
+func (r LargeStructRow) SetID(id int) { r.table.IDs[r.index] = id }
+
+// SetName は Name 列の値を設定します
+func (r LargeStructRow) SetName(name string) { r.table.Names[r.index] = name }
+
+// CopyTo は行の内容を s にコピーします
+func (r LargeStructRow) CopyTo(s *LargeStruct) {
+	t, i := r.table, r.index
+	s.ID = t.IDs[i]
+	s.Name = t.Names[i]
+	s.Data = t.Data[i]
+	s.Metadata = t.Metadata[i]
+	s.Values = t.Values[i]
+}
+
+// Struct は行の内容を LargeStruct として返します（約8KBのコピーが発生します）
+func (r LargeStructRow) Struct() LargeStruct {
+	var s LargeStruct
+	r.CopyTo(&s)
+	return s
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"slice_practice/sliceaccess"
+)
+
+func TestLargeStructTableRoundTrip(t *testing.T) {
+	slice := createLargeStructSlice(5)
+	table := NewLargeStructTableFrom(slice)
+	if table.Len() != 5 {
+		t.Fatalf("Len = %d; want 5", table.Len())
+	}
+	if got := table.ToSlice(); !reflect.DeepEqual(got, slice) {
+		t.Error("ToSlice does not match the original slice")
+	}
+
+	middle, err := table.Middle()
+	if err != nil || middle.Index() != 2 || middle.ID() != 2 || middle.Name() != "Item_2" {
+		t.Fatalf("Middle = row %d (ID %d, %q), %v", middle.Index(), middle.ID(), middle.Name(), err)
+	}
+	if middle.Data()[999] != slice[2].Data[999] || middle.Values()[1] != slice[2].Values[1] {
+		t.Error("Data or Values differ from the original")
+	}
+
+	// 行のビューは Append による再割り当ての後も現在の列を読む
+	for i := range 100 {
+		table.Append(&LargeStruct{ID: 5 + i})
+	}
+	middle.SetID(42)
+	if table.IDs[2] != 42 || middle.Struct().ID != 42 {
+		t.Errorf("SetID after Append: IDs[2] = %d", table.IDs[2])
+	}
+
+	if _, err := NewLargeStructTable(0).Middle(); !errors.Is(err, sliceaccess.ErrEmptySlice) {
+		t.Errorf("Middle on empty table error = %v; want ErrEmptySlice", err)
+	}
+}
+
+// tableBenchLen はテーブルのベンチマークの行数です。1行が約8KBなので、
+// []LargeStruct と LargeStructTable を合わせて約160MBになります。
+const tableBenchLen = 10000
+
+// tableBenchFixture は各ベンチマークで共有する []LargeStruct と、それを変換した LargeStructTable です
+var tableBenchFixture = sync.OnceValues(func() ([]LargeStruct, *LargeStructTable) {
+	slice := createLargeStructSlice(tableBenchLen)
+	return slice, NewLargeStructTableFrom(slice)
+})
+
+// ID の走査: []LargeStruct は要素ごとに約8KB離れた ID を読み、LargeStructTable は連続した IDs を読む
+func BenchmarkScanID(b *testing.B) {
+	all, table := tableBenchFixture()
+	for _, length := range []int{1000, tableBenchLen} {
+		slice, ids := all[:length], table.IDs[:length]
+
+		b.Run(fmt.Sprintf("slice/len=%d", length), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sum := 0
+				for j := range slice {
+					sum += slice[j].ID
+				}
+				intSink = sum
+			}
+		})
+
+		b.Run(fmt.Sprintf("table/len=%d", length), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sum := 0
+				for _, id := range ids {
+					sum += id
+				}
+				intSink = sum
+			}
+		})
+	}
+}
+
+// Name の走査（文字列ヘッダのみ読む）
+func BenchmarkScanName(b *testing.B) {
+	slice, table := tableBenchFixture()
+
+	b.Run("slice", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			n := 0
+			for j := range slice {
+				n += len(slice[j].Name)
+			}
+			intSink = n
+		}
+	})
+
+	b.Run("table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			n := 0
+			for _, name := range table.Names {
+				n += len(name)
+			}
+			intSink = n
+		}
+	})
+}
+
+// 真ん中の行へのアクセス: 既存のポインタ・値アクセスと、行のビュー経由のアクセス
+func BenchmarkMiddleRow(b *testing.B) {
+	slice, table := tableBenchFixture()
+
+	b.Run("slice/pointer", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			largePtrSink = getLargeStructWithPointer(slice)
+		}
+	})
+	b.Run("slice/index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			largeStructSink = getLargeStructWithIndex(slice)
+		}
+	})
+	b.Run("table/ID", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			row, _ := table.Middle()
+			intSink = row.ID()
+		}
+	})
+	b.Run("table/Data", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			row, _ := table.Middle()
+			intSink = row.Data()[500]
+		}
+	})
+	b.Run("table/Struct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			row, _ := table.Middle()
+			largeStructSink = row.Struct()
+		}
+	})
+}